@@ -20,6 +20,9 @@ pod = mgmt.makeVertexLabel('Pod').make();
 permissionSet = mgmt.makeVertexLabel('PermissionSet').make();
 volume = mgmt.makeVertexLabel('Volume').make();
 endpoint = mgmt.makeVertexLabel('Endpoint').make();
+cloudIdentity = mgmt.makeVertexLabel('CloudIdentity').make();
+namespaceVtx = mgmt.makeVertexLabel('Namespace').make();
+secret = mgmt.makeVertexLabel('Secret').make();
 
 // Create our edge labels and connections
 permissionDiscover = mgmt.makeEdgeLabel('PERMISSION_DISCOVER').multiplicity(MULTI).make();
@@ -43,17 +46,25 @@ mgmt.addConnection(hostTraverse, volume, volume);
 sharedPs = mgmt.makeEdgeLabel('SHARE_PS_NAMESPACE').multiplicity(MULTI).make();
 mgmt.addConnection(sharedPs, container, container);
 
+volumeShare = mgmt.makeEdgeLabel('VOLUME_SHARE').multiplicity(MULTI).make();
+mgmt.addConnection(volumeShare, container, container);
+
 containerAttach = mgmt.makeEdgeLabel('CONTAINER_ATTACH').multiplicity(ONE2MANY).make();
 mgmt.addConnection(containerAttach, pod, container);
 
 idAssume = mgmt.makeEdgeLabel('IDENTITY_ASSUME').multiplicity(MANY2ONE).make();
 mgmt.addConnection(idAssume, container, identity);
 mgmt.addConnection(idAssume, node, identity);
+mgmt.addConnection(idAssume, secret, identity);
+
+idAssumeCloud = mgmt.makeEdgeLabel('IDENTITY_ASSUME_CLOUD').multiplicity(MULTI).make();
+mgmt.addConnection(idAssumeCloud, identity, cloudIdentity);
+mgmt.addConnection(idAssumeCloud, node, cloudIdentity);
 
-idImpersonate = mgmt.makeEdgeLabel('IDENTITY_IMPERSONATE').multiplicity(MANY2ONE).make();
+idImpersonate = mgmt.makeEdgeLabel('IDENTITY_IMPERSONATE').multiplicity(MULTI).make();
 mgmt.addConnection(idImpersonate, permissionSet, identity);
 
-roleBind = mgmt.makeEdgeLabel('ROLE_BIND').multiplicity(MANY2ONE).make();
+roleBind = mgmt.makeEdgeLabel('ROLE_BIND').multiplicity(MULTI).make();
 mgmt.addConnection(roleBind, permissionSet, permissionSet);
 
 podAttach = mgmt.makeEdgeLabel('POD_ATTACH').multiplicity(ONE2MANY).make();
@@ -64,6 +75,14 @@ podCreate = mgmt.makeEdgeLabel('POD_CREATE').multiplicity(MULTI).make();
 mgmt.addConnection(podCreate, permissionSet, node);
 mgmt.addConnection(podCreate, permissionSet, permissionSet); // self-referencing for large cluster optimizations
 
+webhookTamper = mgmt.makeEdgeLabel('WEBHOOK_TAMPER').multiplicity(MULTI).make();
+mgmt.addConnection(webhookTamper, permissionSet, node);
+mgmt.addConnection(webhookTamper, permissionSet, permissionSet); // self-referencing for large cluster optimizations
+
+workloadCreate = mgmt.makeEdgeLabel('WORKLOAD_CREATE').multiplicity(MULTI).make();
+mgmt.addConnection(workloadCreate, permissionSet, node);
+mgmt.addConnection(workloadCreate, permissionSet, permissionSet); // self-referencing for large cluster optimizations
+
 podPatch = mgmt.makeEdgeLabel('POD_PATCH').multiplicity(MULTI).make();
 mgmt.addConnection(podPatch, permissionSet, pod);
 mgmt.addConnection(podPatch, permissionSet, permissionSet); // self-referencing for large cluster optimizations
@@ -72,17 +91,39 @@ podExec = mgmt.makeEdgeLabel('POD_EXEC').multiplicity(MULTI).make();
 mgmt.addConnection(podExec, permissionSet, pod);
 mgmt.addConnection(podExec, permissionSet, permissionSet); // self-referencing for large cluster optimizations
 
+podPortForward = mgmt.makeEdgeLabel('POD_PORT_FORWARD').multiplicity(MULTI).make();
+mgmt.addConnection(podPortForward, permissionSet, endpoint);
+mgmt.addConnection(podPortForward, permissionSet, permissionSet); // self-referencing for large cluster optimizations
+
+podDebug = mgmt.makeEdgeLabel('POD_DEBUG').multiplicity(MULTI).make();
+mgmt.addConnection(podDebug, permissionSet, pod);
+mgmt.addConnection(podDebug, permissionSet, permissionSet); // self-referencing for large cluster optimizations
+
+nodeProxy = mgmt.makeEdgeLabel('NODE_PROXY').multiplicity(MULTI).make();
+mgmt.addConnection(nodeProxy, permissionSet, node);
+mgmt.addConnection(nodeProxy, permissionSet, permissionSet); // self-referencing for large cluster optimizations
+
+csrApprove = mgmt.makeEdgeLabel('CSR_APPROVE').multiplicity(MULTI).make();
+mgmt.addConnection(csrApprove, identity, identity);
+
 tokenSteal = mgmt.makeEdgeLabel('TOKEN_STEAL').multiplicity(MULTI).make();
 mgmt.addConnection(tokenSteal, volume, identity);
+mgmt.addConnection(tokenSteal, container, identity);
 
 tokenBruteforce = mgmt.makeEdgeLabel('TOKEN_BRUTEFORCE').multiplicity(MULTI).make();
 mgmt.addConnection(tokenBruteforce, permissionSet, identity);
+mgmt.addConnection(tokenBruteforce, permissionSet, secret);
 
 tokenList = mgmt.makeEdgeLabel('TOKEN_LIST').multiplicity(MULTI).make();
 mgmt.addConnection(tokenList, permissionSet, identity);
+mgmt.addConnection(tokenList, permissionSet, secret);
+
+saTokenCreate = mgmt.makeEdgeLabel('SERVICE_ACCOUNT_TOKEN_CREATE').multiplicity(MULTI).make();
+mgmt.addConnection(saTokenCreate, permissionSet, identity);
+mgmt.addConnection(saTokenCreate, permissionSet, permissionSet); // self-referencing for large cluster optimizations
 
-tokenVarLog = mgmt.makeEdgeLabel('TOKEN_VAR_LOG_SYMLINK').multiplicity(ONE2MANY).make();
-mgmt.addConnection(tokenVarLog, container, volume);
+tokenVarLog = mgmt.makeEdgeLabel('TOKEN_VAR_LOG_SYMLINK').multiplicity(MULTI).make();
+mgmt.addConnection(tokenVarLog, container, identity);
 
 nsenter = mgmt.makeEdgeLabel('CE_NSENTER').multiplicity(MANY2ONE).make();
 mgmt.addConnection(nsenter, container, node);
@@ -99,6 +140,18 @@ mgmt.addConnection(privMount, container, node);
 sysPtrace = mgmt.makeEdgeLabel('CE_SYS_PTRACE').multiplicity(MANY2ONE).make();
 mgmt.addConnection(sysPtrace, container, node);
 
+cgroupReleaseAgent = mgmt.makeEdgeLabel('CE_CGROUP_RELEASE_AGENT').multiplicity(MANY2ONE).make();
+mgmt.addConnection(cgroupReleaseAgent, container, node);
+
+dacReadSearch = mgmt.makeEdgeLabel('CE_DAC_READ_SEARCH').multiplicity(MANY2ONE).make();
+mgmt.addConnection(dacReadSearch, container, node);
+
+containerdSock = mgmt.makeEdgeLabel('EXPLOIT_CONTAINERD_SOCK').multiplicity(MANY2ONE).make();
+mgmt.addConnection(containerdSock, container, node);
+
+netMitm = mgmt.makeEdgeLabel('CE_NET_MITM').multiplicity(MULTI).make();
+mgmt.addConnection(netMitm, container, container);
+
 endpointExploit = mgmt.makeEdgeLabel('ENDPOINT_EXPLOIT').multiplicity(MULTI).make();
 mgmt.addConnection(endpointExploit, endpoint, container);
 
@@ -146,10 +199,16 @@ addresses = mgmt.makePropertyKey('addresses').dataType(String.class).cardinality
 protocol = mgmt.makePropertyKey('protocol').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 role = mgmt.makePropertyKey('role').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 roleBinding = mgmt.makePropertyKey('roleBinding').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+kinds = mgmt.makePropertyKey('kinds').dataType(String[].class).cardinality(Cardinality.SINGLE).make();
+provider = mgmt.makePropertyKey('provider').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+reachable = mgmt.makePropertyKey('reachable').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
+psaEnforce = mgmt.makePropertyKey('psaEnforce').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+psaAudit = mgmt.makePropertyKey('psaAudit').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+psaWarn = mgmt.makePropertyKey('psaWarn').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 
 
 // Define properties for each vertex 
-mgmt.addProperties(container, cls, storeID, app, team, service, isNamespaced, namespace, name, image, privileged, privesc, hostPid, 
+mgmt.addProperties(container, cls, storeID, app, team, service, isNamespaced, namespace, name, type, image, privileged, privesc, hostPid, 
     hostIpc, hostNetwork, runAsUser, podName, nodeName, compromised, command, args, capabilities, ports);
 mgmt.addProperties(identity, cls, storeID, app, team, service, name, isNamespaced, namespace, type, critical);
 mgmt.addProperties(node, cls, storeID, app, team, service, name, isNamespaced, namespace, compromised, critical);
@@ -158,6 +217,13 @@ mgmt.addProperties(permissionSet, cls, storeID, app, team, service, name, isName
 mgmt.addProperties(volume, cls, storeID, app, team, service, name, isNamespaced, namespace, type, sourcePath, mountPath, readonly);
 mgmt.addProperties(endpoint, cls, storeID, app, team, service, name, isNamespaced, namespace, serviceEndpoint, serviceDns, addressType, 
     addresses, port, portName, protocol, exposure, compromised);
+mgmt.addProperties(cloudIdentity, cls, storeID, app, team, service, name, isNamespaced, namespace, provider, type);
+mgmt.addProperties(namespaceVtx, cls, storeID, app, team, service, name, isNamespaced, namespace, psaEnforce, psaAudit, psaWarn);
+mgmt.addProperties(secret, cls, storeID, app, team, service, name, isNamespaced, namespace, type, serviceAccount);
+
+// Define properties for each edge
+mgmt.addProperties(workloadCreate, kinds);
+mgmt.addProperties(endpointExploit, reachable);
 
 
 // Create the indexes on vertex properties
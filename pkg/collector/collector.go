@@ -65,6 +65,54 @@ type EndpointIngestor interface {
 	Complete(context.Context) error
 }
 
+// PersistentVolumeIngestor defines the interface to allow an ingestor to consume persistent volume inputs from a collector.
+//
+//go:generate mockery --name PersistentVolumeIngestor --output mockingest --case underscore --filename persistent_volume_ingestor.go --with-expecter
+type PersistentVolumeIngestor interface {
+	IngestPersistentVolume(context.Context, types.PersistentVolumeType) error
+	Complete(context.Context) error
+}
+
+// PersistentVolumeClaimIngestor defines the interface to allow an ingestor to consume persistent volume claim inputs from a collector.
+//
+//go:generate mockery --name PersistentVolumeClaimIngestor --output mockingest --case underscore --filename persistent_volume_claim_ingestor.go --with-expecter
+type PersistentVolumeClaimIngestor interface {
+	IngestPersistentVolumeClaim(context.Context, types.PersistentVolumeClaimType) error
+	Complete(context.Context) error
+}
+
+// ServiceAccountIngestor defines the interface to allow an ingestor to consume service account inputs from a collector.
+//
+//go:generate mockery --name ServiceAccountIngestor --output mockingest --case underscore --filename service_account_ingestor.go --with-expecter
+type ServiceAccountIngestor interface {
+	IngestServiceAccount(context.Context, types.ServiceAccountType) error
+	Complete(context.Context) error
+}
+
+// NetworkPolicyIngestor defines the interface to allow an ingestor to consume network policy inputs from a collector.
+//
+//go:generate mockery --name NetworkPolicyIngestor --output mockingest --case underscore --filename network_policy_ingestor.go --with-expecter
+type NetworkPolicyIngestor interface {
+	IngestNetworkPolicy(context.Context, types.NetworkPolicyType) error
+	Complete(context.Context) error
+}
+
+// NamespaceIngestor defines the interface to allow an ingestor to consume namespace inputs from a collector.
+//
+//go:generate mockery --name NamespaceIngestor --output mockingest --case underscore --filename namespace_ingestor.go --with-expecter
+type NamespaceIngestor interface {
+	IngestNamespace(context.Context, types.NamespaceType) error
+	Complete(context.Context) error
+}
+
+// SecretIngestor defines the interface to allow an ingestor to consume secret metadata inputs from a collector.
+//
+//go:generate mockery --name SecretIngestor --output mockingest --case underscore --filename secret_ingestor.go --with-expecter
+type SecretIngestor interface {
+	IngestSecret(context.Context, types.SecretType) error
+	Complete(context.Context) error
+}
+
 //go:generate mockery --name CollectorClient --output mockcollector --case underscore --filename collector_client.go --with-expecter
 type CollectorClient interface {
 	services.Dependency
@@ -97,6 +145,31 @@ type CollectorClient interface {
 	// Once all the EndpointType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
 	StreamEndpoints(ctx context.Context, ingestor EndpointIngestor) error
 
+	// StreamPersistentVolumes will iterate through all PersistentVolumeType objects collected by the collector and invoke the ingestor.IngestPersistentVolume method on each.
+	// Once all the PersistentVolumeType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamPersistentVolumes(ctx context.Context, ingestor PersistentVolumeIngestor) error
+
+	// StreamPersistentVolumeClaims will iterate through all PersistentVolumeClaimType objects collected by the collector and invoke the ingestor.IngestPersistentVolumeClaim method on each.
+	// Once all the PersistentVolumeClaimType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamPersistentVolumeClaims(ctx context.Context, ingestor PersistentVolumeClaimIngestor) error
+
+	// StreamServiceAccounts will iterate through all ServiceAccountType objects collected by the collector and invoke the ingestor.IngestServiceAccount method on each.
+	// Once all the ServiceAccountType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamServiceAccounts(ctx context.Context, ingestor ServiceAccountIngestor) error
+
+	// StreamNetworkPolicies will iterate through all NetworkPolicyType objects collected by the collector and invoke the ingestor.IngestNetworkPolicy method on each.
+	// Once all the NetworkPolicyType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamNetworkPolicies(ctx context.Context, ingestor NetworkPolicyIngestor) error
+
+	// StreamNamespaces will iterate through all NamespaceType objects collected by the collector and invoke the ingestor.IngestNamespace method on each.
+	// Once all the NamespaceType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamNamespaces(ctx context.Context, ingestor NamespaceIngestor) error
+
+	// StreamSecrets will iterate through all SecretType objects collected by the collector and invoke the ingestor.IngestSecret method on each.
+	// Only the metadata and type of the secrets are collected, the secret values are never requested.
+	// Once all the SecretType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamSecrets(ctx context.Context, ingestor SecretIngestor) error
+
 	// Close cleans up any resources used by the collector client implementation. Client cannot be reused after this call.
 	Close(ctx context.Context) error
 }
@@ -18,6 +18,7 @@ import (
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
 
@@ -27,22 +28,38 @@ import (
 // | |____pods.json
 // | |____endpointslices.discovery.k8s.io.json
 // | |____roles.rbac.authorization.k8s.io.json
+// | |____persistentvolumeclaims.json
+// | |____serviceaccounts.json
+// | |____networkpolicies.networking.k8s.io.json
+// | |____secrets.json (optional, secrets metadata only)
 // |____<namespace>
 // | |____rolebindings.rbac.authorization.k8s.io.json
 // | |____pods.json
 // | |____endpointslices.discovery.k8s.io.json
 // | |____roles.rbac.authorization.k8s.io.json
+// | |____persistentvolumeclaims.json
+// | |____serviceaccounts.json
+// | |____networkpolicies.networking.k8s.io.json
+// | |____secrets.json (optional, secrets metadata only)
 // |____nodes.json
 // |____clusterroles.rbac.authorization.k8s.io.json
 // |____clusterrolebindings.rbac.authorization.k8s.io.json
+// |____persistentvolumes.json
+// |____namespaces.json
 const (
-	nodePath                = "nodes.json"
-	endpointPath            = "endpointslices.discovery.k8s.io.json"
-	clusterRolesPath        = "clusterroles.rbac.authorization.k8s.io.json"
-	clusterRoleBindingsPath = "clusterrolebindings.rbac.authorization.k8s.io.json"
-	podPath                 = "pods.json"
-	rolesPath               = "roles.rbac.authorization.k8s.io.json"
-	roleBindingsPath        = "rolebindings.rbac.authorization.k8s.io.json"
+	nodePath                   = "nodes.json"
+	endpointPath               = "endpointslices.discovery.k8s.io.json"
+	clusterRolesPath           = "clusterroles.rbac.authorization.k8s.io.json"
+	clusterRoleBindingsPath    = "clusterrolebindings.rbac.authorization.k8s.io.json"
+	podPath                    = "pods.json"
+	rolesPath                  = "roles.rbac.authorization.k8s.io.json"
+	roleBindingsPath           = "rolebindings.rbac.authorization.k8s.io.json"
+	persistentVolumesPath      = "persistentvolumes.json"
+	persistentVolumeClaimsPath = "persistentvolumeclaims.json"
+	serviceAccountsPath        = "serviceaccounts.json"
+	networkPoliciesPath        = "networkpolicies.networking.k8s.io.json"
+	namespacesPath             = "namespaces.json"
+	secretsPath                = "secrets.json"
 )
 
 const (
@@ -346,6 +363,277 @@ func (c *FileCollector) StreamClusterRoleBindings(ctx context.Context, ingestor
 	return ingestor.Complete(ctx)
 }
 
+// streamPersistentVolumeClaimsNamespace streams the persistent volume claims in a single file, corresponding to a cluster namespace.
+func (c *FileCollector) streamPersistentVolumeClaimsNamespace(ctx context.Context, fp string, ingestor PersistentVolumeClaimIngestor) error {
+	list, err := readList[corev1.PersistentVolumeClaimList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(telemetry.MetricCollectorPersistentVolumeClaimsCount, c.tags, 1)
+		i := types.PersistentVolumeClaimType(&item)
+		err = ingestor.IngestPersistentVolumeClaim(ctx, i)
+		if err != nil {
+			return fmt.Errorf("processing K8s persistent volume claim %s: %w", i.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCollector) StreamPersistentVolumeClaims(ctx context.Context, ingestor PersistentVolumeClaimIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourcePersistentVolumeClaims)
+	defer span.Finish()
+
+	err := filepath.WalkDir(c.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if path == c.cfg.Directory || !d.IsDir() {
+			// Skip files
+			return nil
+		}
+
+		fp := filepath.Join(path, persistentVolumeClaimsPath)
+		if fileMissing(fp) {
+			c.log.Debugf("No persistent volume claims file %s, skipping", fp)
+
+			return nil
+		}
+
+		c.log.Debugf("Streaming persistent volume claims from file %s", fp)
+
+		return c.streamPersistentVolumeClaimsNamespace(ctx, fp, ingestor)
+	})
+
+	if err != nil {
+		return fmt.Errorf("file collector stream persistent volume claims: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+func (c *FileCollector) StreamPersistentVolumes(ctx context.Context, ingestor PersistentVolumeIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourcePersistentVolumes)
+	defer span.Finish()
+
+	fp := filepath.Join(c.cfg.Directory, persistentVolumesPath)
+	if fileMissing(fp) {
+		c.log.Debugf("No persistent volumes file %s, skipping", fp)
+
+		return ingestor.Complete(ctx)
+	}
+
+	c.log.Debugf("Streaming persistent volumes from file %s", fp)
+
+	list, err := readList[corev1.PersistentVolumeList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(telemetry.MetricCollectorPersistentVolumesCount, c.tags, 1)
+		i := types.PersistentVolumeType(&item)
+		err = ingestor.IngestPersistentVolume(ctx, i)
+		if err != nil {
+			return fmt.Errorf("processing K8s persistent volume %s: %w", i.Name, err)
+		}
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// streamServiceAccountsNamespace streams the service accounts in a single file, corresponding to a cluster namespace.
+func (c *FileCollector) streamServiceAccountsNamespace(ctx context.Context, fp string, ingestor ServiceAccountIngestor) error {
+	list, err := readList[corev1.ServiceAccountList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(telemetry.MetricCollectorServiceAccountsCount, c.tags, 1)
+		i := types.ServiceAccountType(&item)
+		err = ingestor.IngestServiceAccount(ctx, i)
+		if err != nil {
+			return fmt.Errorf("processing K8s service account %s: %w", i.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCollector) StreamServiceAccounts(ctx context.Context, ingestor ServiceAccountIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourceServiceAccounts)
+	defer span.Finish()
+
+	err := filepath.WalkDir(c.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if path == c.cfg.Directory || !d.IsDir() {
+			// Skip files
+			return nil
+		}
+
+		fp := filepath.Join(path, serviceAccountsPath)
+		if fileMissing(fp) {
+			c.log.Debugf("No service accounts file %s, skipping", fp)
+
+			return nil
+		}
+
+		c.log.Debugf("Streaming service accounts from file %s", fp)
+
+		return c.streamServiceAccountsNamespace(ctx, fp, ingestor)
+	})
+
+	if err != nil {
+		return fmt.Errorf("file collector stream service accounts: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// streamNetworkPoliciesNamespace streams the network policies in a single file, corresponding to a cluster namespace.
+func (c *FileCollector) streamNetworkPoliciesNamespace(ctx context.Context, fp string, ingestor NetworkPolicyIngestor) error {
+	list, err := readList[netv1.NetworkPolicyList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(telemetry.MetricCollectorNetworkPoliciesCount, c.tags, 1)
+		i := types.NetworkPolicyType(&item)
+		err = ingestor.IngestNetworkPolicy(ctx, i)
+		if err != nil {
+			return fmt.Errorf("processing K8s network policy %s: %w", i.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCollector) StreamNetworkPolicies(ctx context.Context, ingestor NetworkPolicyIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourceNetworkPolicies)
+	defer span.Finish()
+
+	err := filepath.WalkDir(c.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if path == c.cfg.Directory || !d.IsDir() {
+			// Skip files
+			return nil
+		}
+
+		fp := filepath.Join(path, networkPoliciesPath)
+		if fileMissing(fp) {
+			c.log.Debugf("No network policies file %s, skipping", fp)
+
+			return nil
+		}
+
+		c.log.Debugf("Streaming network policies from file %s", fp)
+
+		return c.streamNetworkPoliciesNamespace(ctx, fp, ingestor)
+	})
+
+	if err != nil {
+		return fmt.Errorf("file collector stream network policies: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+func (c *FileCollector) StreamNamespaces(ctx context.Context, ingestor NamespaceIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourceNamespaces)
+	defer span.Finish()
+
+	fp := filepath.Join(c.cfg.Directory, namespacesPath)
+	if fileMissing(fp) {
+		c.log.Debugf("No namespaces file %s, skipping", fp)
+
+		return ingestor.Complete(ctx)
+	}
+
+	c.log.Debugf("Streaming namespaces from file %s", fp)
+
+	list, err := readList[corev1.NamespaceList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(telemetry.MetricCollectorNamespacesCount, c.tags, 1)
+		i := types.NamespaceType(&item)
+		err = ingestor.IngestNamespace(ctx, i)
+		if err != nil {
+			return fmt.Errorf("processing K8s namespace %s: %w", i.Name, err)
+		}
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// streamSecretsNamespace streams the secrets in a single file, corresponding to a cluster namespace. Any secret value
+// present in the file is dropped before being passed to the ingestor.
+func (c *FileCollector) streamSecretsNamespace(ctx context.Context, fp string, ingestor SecretIngestor) error {
+	list, err := readList[corev1.SecretList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(telemetry.MetricCollectorSecretsCount, c.tags, 1)
+		item.Data = nil
+		item.StringData = nil
+		i := types.SecretType(&item)
+		err = ingestor.IngestSecret(ctx, i)
+		if err != nil {
+			return fmt.Errorf("processing K8s secret %s: %w", i.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCollector) StreamSecrets(ctx context.Context, ingestor SecretIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourceSecrets)
+	defer span.Finish()
+
+	err := filepath.WalkDir(c.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if path == c.cfg.Directory || !d.IsDir() {
+			// Skip files
+			return nil
+		}
+
+		fp := filepath.Join(path, secretsPath)
+
+		// The collection of secrets metadata is opt-in in the collection scripts, so a missing file means no secrets
+		if _, err := os.Stat(fp); errors.Is(err, fs.ErrNotExist) {
+			c.log.Debugf("No secrets file %s, skipping", fp)
+
+			return nil
+		}
+
+		c.log.Debugf("Streaming secrets from file %s", fp)
+
+		return c.streamSecretsNamespace(ctx, fp, ingestor)
+	})
+
+	if err != nil {
+		return fmt.Errorf("file collector stream secrets: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// fileMissing returns whether an input file is absent from the collected data. Resources added to the collection over
+// time are optional, so that data collected with older versions of the collection scripts can still be ingested.
+func fileMissing(fp string) bool {
+	_, err := os.Stat(fp)
+
+	return errors.Is(err, fs.ErrNotExist)
+}
+
 // readList loads a list of K8s API objects into memory from a JSON file on disk.
 // NOTE: This implementation reads the entire array of objects from the file into memory at once.
 func readList[Tl types.ListInputType](ctx context.Context, inputPath string) (Tl, error) {
@@ -2,10 +2,13 @@ package collector
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	mocks "github.com/DataDog/KubeHound/pkg/collector/mockingest"
 	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -145,3 +148,158 @@ func TestFileCollector_StreamEndpoints(t *testing.T) {
 	err := c.StreamEndpoints(ctx, i)
 	assert.NoError(t, err)
 }
+
+func TestFileCollector_StreamPersistentVolumes(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewPersistentVolumeIngestor(t)
+
+	i.EXPECT().IngestPersistentVolume(mock.Anything, mock.AnythingOfType("types.PersistentVolumeType")).Return(nil)
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamPersistentVolumes(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamPersistentVolumeClaims(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewPersistentVolumeClaimIngestor(t)
+
+	i.EXPECT().IngestPersistentVolumeClaim(mock.Anything, mock.AnythingOfType("types.PersistentVolumeClaimType")).Return(nil)
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamPersistentVolumeClaims(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamPersistentVolumes_MissingFile(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+
+	// Data collected with older collection scripts has no persistent volumes or claims files
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "namespace-1"), 0o700))
+	c.cfg.Directory = dir
+
+	pvi := mocks.NewPersistentVolumeIngestor(t)
+	pvi.EXPECT().Complete(mock.Anything).Return(nil).Once()
+	assert.NoError(t, c.StreamPersistentVolumes(ctx, pvi))
+
+	pvci := mocks.NewPersistentVolumeClaimIngestor(t)
+	pvci.EXPECT().Complete(mock.Anything).Return(nil).Once()
+	assert.NoError(t, c.StreamPersistentVolumeClaims(ctx, pvci))
+}
+
+func TestFileCollector_StreamServiceAccounts(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewServiceAccountIngestor(t)
+
+	i.EXPECT().IngestServiceAccount(mock.Anything, mock.AnythingOfType("types.ServiceAccountType")).Return(nil)
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamServiceAccounts(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamServiceAccounts_MissingFile(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewServiceAccountIngestor(t)
+
+	// Data collected with older collection scripts has no service accounts files
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "namespace-1"), 0o700))
+	c.cfg.Directory = dir
+
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamServiceAccounts(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamNetworkPolicies(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewNetworkPolicyIngestor(t)
+
+	i.EXPECT().IngestNetworkPolicy(mock.Anything, mock.AnythingOfType("types.NetworkPolicyType")).Return(nil)
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamNetworkPolicies(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamNetworkPolicies_MissingFile(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewNetworkPolicyIngestor(t)
+
+	// Data collected with older collection scripts has no network policies files
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "namespace-1"), 0o700))
+	c.cfg.Directory = dir
+
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamNetworkPolicies(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamNamespaces(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewNamespaceIngestor(t)
+
+	i.EXPECT().IngestNamespace(mock.Anything, mock.AnythingOfType("types.NamespaceType")).Return(nil)
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamNamespaces(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamNamespaces_MissingFile(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewNamespaceIngestor(t)
+
+	// Data collected with older collection scripts has no namespaces file
+	c.cfg.Directory = t.TempDir()
+
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamNamespaces(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamSecrets(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewSecretIngestor(t)
+
+	// Secret values present in the files must never reach the ingestor
+	i.EXPECT().IngestSecret(mock.Anything, mock.MatchedBy(func(s types.SecretType) bool {
+		return s.Data == nil && s.StringData == nil
+	})).Return(nil).Twice()
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamSecrets(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamSecrets_MissingFile(t *testing.T) {
+	c := NewTestFileCollector(t)
+	ctx := context.Background()
+	i := mocks.NewSecretIngestor(t)
+
+	// Namespaces collected without the secrets metadata have no secrets file
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "namespace-1"), 0o700))
+	c.cfg.Directory = dir
+
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamSecrets(ctx, i)
+	assert.NoError(t, err)
+}
@@ -11,11 +11,14 @@ import (
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/pager"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -25,6 +28,7 @@ import (
 // FileCollector implements a collector based on local K8s API json files generated outside the KubeHound application via e.g kubectl.
 type k8sAPICollector struct {
 	clientset kubernetes.Interface
+	metadata  metadata.Interface // Metadata only client, used for sensitive resources such as secrets
 	log       *log.KubehoundLogger
 	rl        ratelimit.Limiter
 	cfg       *config.K8SAPICollectorConfig
@@ -63,9 +67,15 @@ func NewK8sAPICollector(ctx context.Context, cfg *config.KubehoundConfig) (Colle
 		return nil, fmt.Errorf("getting kubernetes config: %w", err)
 	}
 
+	metadataClient, err := metadata.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("getting kubernetes metadata config: %w", err)
+	}
+
 	return &k8sAPICollector{
 		cfg:       cfg.Collector.Live,
 		clientset: clientset,
+		metadata:  metadataClient,
 		log:       l,
 		rl:        ratelimit.New(cfg.Collector.Live.RateLimitPerSecond), // per second
 		tags:      baseTags,
@@ -391,3 +401,278 @@ func (c *k8sAPICollector) StreamClusterRoleBindings(ctx context.Context, ingesto
 	}
 	return ingestor.Complete(ctx)
 }
+
+// streamPersistentVolumeClaimsNamespace streams the persistent volume claim objects corresponding to a cluster namespace.
+func (c *k8sAPICollector) streamPersistentVolumeClaimsNamespace(ctx context.Context, namespace string, ingestor PersistentVolumeClaimIngestor) error {
+	err := c.checkNamespaceExists(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.ListOptions{}
+
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s persistent volume claims for namespace %s: %w", namespace, err)
+		}
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(telemetry.MetricCollectorPersistentVolumeClaimsCount, c.tags, 1)
+		c.rl.Take()
+		item := obj.(*corev1.PersistentVolumeClaim)
+		err := ingestor.IngestPersistentVolumeClaim(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s persistent volume claim %s for namespace %s: %w", item.Name, namespace, err)
+		}
+		return nil
+	})
+}
+
+func (c *k8sAPICollector) StreamPersistentVolumeClaims(ctx context.Context, ingestor PersistentVolumeClaimIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourcePersistentVolumeClaims)
+	defer span.Finish()
+
+	// passing an empty namespace will collect all namespaces
+	err := c.streamPersistentVolumeClaimsNamespace(ctx, "", ingestor)
+	if err != nil {
+		return err
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+func (c *k8sAPICollector) StreamPersistentVolumes(ctx context.Context, ingestor PersistentVolumeIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourcePersistentVolumes)
+	defer span.Finish()
+
+	opts := metav1.ListOptions{}
+
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.CoreV1().PersistentVolumes().List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s persistent volumes: %w", err)
+		}
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	err := pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(telemetry.MetricCollectorPersistentVolumesCount, c.tags, 1)
+		c.rl.Take()
+		item := obj.(*corev1.PersistentVolume)
+		err := ingestor.IngestPersistentVolume(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s persistent volume %s: %w", item.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return ingestor.Complete(ctx)
+}
+
+// streamServiceAccountsNamespace streams the service account objects corresponding to a cluster namespace.
+func (c *k8sAPICollector) streamServiceAccountsNamespace(ctx context.Context, namespace string, ingestor ServiceAccountIngestor) error {
+	err := c.checkNamespaceExists(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.ListOptions{}
+
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s service accounts for namespace %s: %w", namespace, err)
+		}
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(telemetry.MetricCollectorServiceAccountsCount, c.tags, 1)
+		c.rl.Take()
+		item := obj.(*corev1.ServiceAccount)
+		err := ingestor.IngestServiceAccount(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s service account %s for namespace %s: %w", item.Name, namespace, err)
+		}
+		return nil
+	})
+}
+
+func (c *k8sAPICollector) StreamServiceAccounts(ctx context.Context, ingestor ServiceAccountIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourceServiceAccounts)
+	defer span.Finish()
+
+	// passing an empty namespace will collect all namespaces
+	err := c.streamServiceAccountsNamespace(ctx, "", ingestor)
+	if err != nil {
+		return err
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// streamNetworkPoliciesNamespace streams the network policy objects corresponding to a cluster namespace.
+func (c *k8sAPICollector) streamNetworkPoliciesNamespace(ctx context.Context, namespace string, ingestor NetworkPolicyIngestor) error {
+	err := c.checkNamespaceExists(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.ListOptions{}
+
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s network policies for namespace %s: %w", namespace, err)
+		}
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(telemetry.MetricCollectorNetworkPoliciesCount, c.tags, 1)
+		c.rl.Take()
+		item := obj.(*netv1.NetworkPolicy)
+		err := ingestor.IngestNetworkPolicy(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s network policy %s for namespace %s: %w", item.Name, namespace, err)
+		}
+		return nil
+	})
+}
+
+func (c *k8sAPICollector) StreamNetworkPolicies(ctx context.Context, ingestor NetworkPolicyIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourceNetworkPolicies)
+	defer span.Finish()
+
+	// passing an empty namespace will collect all namespaces
+	err := c.streamNetworkPoliciesNamespace(ctx, "", ingestor)
+	if err != nil {
+		return err
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+func (c *k8sAPICollector) StreamNamespaces(ctx context.Context, ingestor NamespaceIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourceNamespaces)
+	defer span.Finish()
+
+	opts := metav1.ListOptions{}
+
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.CoreV1().Namespaces().List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s namespaces: %w", err)
+		}
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	err := pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(telemetry.MetricCollectorNamespacesCount, c.tags, 1)
+		c.rl.Take()
+		item := obj.(*corev1.Namespace)
+		err := ingestor.IngestNamespace(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s namespace %s: %w", item.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return ingestor.Complete(ctx)
+}
+
+// Built-in secret types. The type of a secret is not part of its metadata, so secrets are listed with a dedicated
+// field selector for each type.
+var secretTypes = []corev1.SecretType{
+	corev1.SecretTypeOpaque,
+	corev1.SecretTypeServiceAccountToken,
+	corev1.SecretTypeDockercfg,
+	corev1.SecretTypeDockerConfigJson,
+	corev1.SecretTypeBasicAuth,
+	corev1.SecretTypeSSHAuth,
+	corev1.SecretTypeTLS,
+	corev1.SecretTypeBootstrapToken,
+}
+
+// secretTypeSelectors returns the field selector matching the secrets of each built-in type. An additional selector
+// with an empty type matches the secrets of any custom type.
+func secretTypeSelectors() map[corev1.SecretType]string {
+	selectors := make(map[corev1.SecretType]string, len(secretTypes)+1)
+	custom := make([]fields.Selector, 0, len(secretTypes))
+	for _, t := range secretTypes {
+		selectors[t] = fields.OneTermEqualSelector("type", string(t)).String()
+		custom = append(custom, fields.OneTermNotEqualSelector("type", string(t)))
+	}
+	selectors[""] = fields.AndSelectors(custom...).String()
+
+	return selectors
+}
+
+// streamSecretsType streams the metadata of the secrets matching a type field selector, across all namespaces. Secrets
+// are listed as PartialObjectMetadata so the secret values are never requested from the API server.
+func (c *k8sAPICollector) streamSecretsType(ctx context.Context, secretType corev1.SecretType, selector string, ingestor SecretIngestor) error {
+	opts := metav1.ListOptions{FieldSelector: selector}
+	secrets := c.metadata.Resource(corev1.SchemeGroupVersion.WithResource("secrets"))
+
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := secrets.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s secrets metadata for type %s: %w", secretType, err)
+		}
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(telemetry.MetricCollectorSecretsCount, c.tags, 1)
+		c.rl.Take()
+		item := obj.(*metav1.PartialObjectMetadata)
+		secret := &corev1.Secret{
+			ObjectMeta: item.ObjectMeta,
+			Type:       secretType,
+		}
+		err := ingestor.IngestSecret(ctx, secret)
+		if err != nil {
+			return fmt.Errorf("processing K8s secret %s for namespace %s: %w", item.Name, item.Namespace, err)
+		}
+		return nil
+	})
+}
+
+func (c *k8sAPICollector) StreamSecrets(ctx context.Context, ingestor SecretIngestor) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, telemetry.SpanOperationStream, tracer.Measured())
+	span.SetTag(telemetry.TagKeyResource, telemetry.TagResourceSecrets)
+	defer span.Finish()
+
+	for secretType, selector := range secretTypeSelectors() {
+		err := c.streamSecretsType(ctx, secretType, selector, ingestor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ingestor.Complete(ctx)
+}
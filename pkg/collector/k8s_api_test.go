@@ -6,16 +6,20 @@ import (
 
 	mocks "github.com/DataDog/KubeHound/pkg/collector/mockingest"
 	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
 	"github.com/DataDog/KubeHound/pkg/telemetry/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/ratelimit"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	clienttesting "k8s.io/client-go/testing"
 )
 
 func NewTestK8sAPICollector(ctx context.Context, clientset *fake.Clientset) CollectorClient {
@@ -679,3 +683,478 @@ func Test_k8sAPICollector_StreamEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func fakePersistentVolume(name string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+func Test_k8sAPICollector_StreamPersistentVolumes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// 0 persistent volumes found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.PersistentVolumeIngestor) {
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewPersistentVolumeIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clientset, m
+	}
+
+	// Listing all the persistent volumes in the cluster
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.PersistentVolumeIngestor) {
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				fakePersistentVolume("name1"),
+				fakePersistentVolume("name2"),
+			}...,
+		)
+		m := mocks.NewPersistentVolumeIngestor(t)
+		m.EXPECT().IngestPersistentVolume(mock.Anything, mock.AnythingOfType("types.PersistentVolumeType")).Return(nil).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.PersistentVolumeIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all persistent volumes",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamPersistentVolumes(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamPersistentVolumes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func fakePersistentVolumeClaim(name string, namespace string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func Test_k8sAPICollector_StreamPersistentVolumeClaims(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// 0 persistent volume claims found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.PersistentVolumeClaimIngestor) {
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewPersistentVolumeClaimIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clientset, m
+	}
+
+	// Listing all the persistent volume claims from all namespaces
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.PersistentVolumeClaimIngestor) {
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				fakePersistentVolumeClaim("namespace1", "name1"),
+				fakePersistentVolumeClaim("namespace2", "name2"),
+			}...,
+		)
+		m := mocks.NewPersistentVolumeClaimIngestor(t)
+		m.EXPECT().IngestPersistentVolumeClaim(mock.Anything, mock.AnythingOfType("types.PersistentVolumeClaimType")).Return(nil).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.PersistentVolumeClaimIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespace",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamPersistentVolumeClaims(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamPersistentVolumeClaims() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func fakeServiceAccount(name string, namespace string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func Test_k8sAPICollector_StreamServiceAccounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// 0 service accounts found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.ServiceAccountIngestor) {
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewServiceAccountIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clientset, m
+	}
+
+	// Listing all the service accounts from all namespaces
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.ServiceAccountIngestor) {
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				fakeServiceAccount("namespace1", "name1"),
+				fakeServiceAccount("namespace2", "name2"),
+			}...,
+		)
+		m := mocks.NewServiceAccountIngestor(t)
+		m.EXPECT().IngestServiceAccount(mock.Anything, mock.AnythingOfType("types.ServiceAccountType")).Return(nil).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.ServiceAccountIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespace",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamServiceAccounts(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamServiceAccounts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func fakeNetworkPolicy(name string, namespace string) *netv1.NetworkPolicy {
+	return &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func Test_k8sAPICollector_StreamNetworkPolicies(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// 0 network policies found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.NetworkPolicyIngestor) {
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewNetworkPolicyIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clientset, m
+	}
+
+	// Listing all the network policies from all namespaces
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.NetworkPolicyIngestor) {
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				fakeNetworkPolicy("namespace1", "name1"),
+				fakeNetworkPolicy("namespace2", "name2"),
+			}...,
+		)
+		m := mocks.NewNetworkPolicyIngestor(t)
+		m.EXPECT().IngestNetworkPolicy(mock.Anything, mock.AnythingOfType("types.NetworkPolicyType")).Return(nil).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.NetworkPolicyIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespace",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamNetworkPolicies(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamNetworkPolicies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func fakeNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+func Test_k8sAPICollector_StreamNamespaces(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// 0 namespaces found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.NamespaceIngestor) {
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewNamespaceIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clientset, m
+	}
+
+	// Listing all the namespaces in the cluster
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.NamespaceIngestor) {
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				fakeNamespace("name1"),
+				fakeNamespace("name2"),
+			}...,
+		)
+		m := mocks.NewNamespaceIngestor(t)
+		m.EXPECT().IngestNamespace(mock.Anything, mock.AnythingOfType("types.NamespaceType")).Return(nil).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.NamespaceIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespace",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamNamespaces(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamNamespaces() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func fakeSecretMetadata(name string, namespace string, annotations map[string]string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+	}
+}
+
+// newFakeSecretsMetadataClient returns a fake metadata client listing the provided secrets for each type field
+// selector, as the fake client does not support field selectors.
+func newFakeSecretsMetadataClient(secrets map[string][]*metav1.PartialObjectMetadata) *metadatafake.FakeMetadataClient {
+	client := metadatafake.NewSimpleMetadataClient(metadatafake.NewTestScheme())
+	client.PrependReactor("list", "secrets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		selector := action.(clienttesting.ListAction).GetListRestrictions().Fields.String()
+		list := &metav1.List{}
+		for _, s := range secrets[selector] {
+			list.Items = append(list.Items, runtime.RawExtension{Object: s})
+		}
+
+		return true, list, nil
+	})
+
+	return client
+}
+
+func Test_k8sAPICollector_StreamSecrets(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// 0 secrets found
+	test1 := func(t *testing.T) (*metadatafake.FakeMetadataClient, *mocks.SecretIngestor) {
+		client := newFakeSecretsMetadataClient(nil)
+		m := mocks.NewSecretIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return client, m
+	}
+
+	// Listing the secrets of all types in the cluster
+	test2 := func(t *testing.T) (*metadatafake.FakeMetadataClient, *mocks.SecretIngestor) {
+		client := newFakeSecretsMetadataClient(map[string][]*metav1.PartialObjectMetadata{
+			"type=kubernetes.io/service-account-token": {
+				fakeSecretMetadata("name1-token", "namespace1", map[string]string{
+					corev1.ServiceAccountNameKey: "name1",
+				}),
+			},
+			"type=Opaque": {
+				fakeSecretMetadata("name2", "namespace2", nil),
+			},
+		})
+		m := mocks.NewSecretIngestor(t)
+		m.EXPECT().IngestSecret(mock.Anything, mock.MatchedBy(func(s types.SecretType) bool {
+			return s.Name == "name1-token" && s.Type == corev1.SecretTypeServiceAccountToken &&
+				s.Annotations[corev1.ServiceAccountNameKey] == "name1"
+		})).Return(nil).Once()
+		m.EXPECT().IngestSecret(mock.Anything, mock.MatchedBy(func(s types.SecretType) bool {
+			return s.Name == "name2" && s.Type == corev1.SecretTypeOpaque
+		})).Return(nil).Once()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+		return client, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*metadatafake.FakeMetadataClient, *mocks.SecretIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all secret types",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, fake.NewSimpleClientset())
+			c.(*k8sAPICollector).metadata = client
+			if err := c.StreamSecrets(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -287,6 +287,92 @@ func (_c *CollectorClient_StreamEndpoints_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// StreamNamespaces provides a mock function with given fields: ctx, ingestor
+func (_m *CollectorClient) StreamNamespaces(ctx context.Context, ingestor collector.NamespaceIngestor) error {
+	ret := _m.Called(ctx, ingestor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, collector.NamespaceIngestor) error); ok {
+		r0 = rf(ctx, ingestor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CollectorClient_StreamNamespaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamNamespaces'
+type CollectorClient_StreamNamespaces_Call struct {
+	*mock.Call
+}
+
+// StreamNamespaces is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingestor collector.NamespaceIngestor
+func (_e *CollectorClient_Expecter) StreamNamespaces(ctx interface{}, ingestor interface{}) *CollectorClient_StreamNamespaces_Call {
+	return &CollectorClient_StreamNamespaces_Call{Call: _e.mock.On("StreamNamespaces", ctx, ingestor)}
+}
+
+func (_c *CollectorClient_StreamNamespaces_Call) Run(run func(ctx context.Context, ingestor collector.NamespaceIngestor)) *CollectorClient_StreamNamespaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(collector.NamespaceIngestor))
+	})
+	return _c
+}
+
+func (_c *CollectorClient_StreamNamespaces_Call) Return(_a0 error) *CollectorClient_StreamNamespaces_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CollectorClient_StreamNamespaces_Call) RunAndReturn(run func(context.Context, collector.NamespaceIngestor) error) *CollectorClient_StreamNamespaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamNetworkPolicies provides a mock function with given fields: ctx, ingestor
+func (_m *CollectorClient) StreamNetworkPolicies(ctx context.Context, ingestor collector.NetworkPolicyIngestor) error {
+	ret := _m.Called(ctx, ingestor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, collector.NetworkPolicyIngestor) error); ok {
+		r0 = rf(ctx, ingestor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CollectorClient_StreamNetworkPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamNetworkPolicies'
+type CollectorClient_StreamNetworkPolicies_Call struct {
+	*mock.Call
+}
+
+// StreamNetworkPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingestor collector.NetworkPolicyIngestor
+func (_e *CollectorClient_Expecter) StreamNetworkPolicies(ctx interface{}, ingestor interface{}) *CollectorClient_StreamNetworkPolicies_Call {
+	return &CollectorClient_StreamNetworkPolicies_Call{Call: _e.mock.On("StreamNetworkPolicies", ctx, ingestor)}
+}
+
+func (_c *CollectorClient_StreamNetworkPolicies_Call) Run(run func(ctx context.Context, ingestor collector.NetworkPolicyIngestor)) *CollectorClient_StreamNetworkPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(collector.NetworkPolicyIngestor))
+	})
+	return _c
+}
+
+func (_c *CollectorClient_StreamNetworkPolicies_Call) Return(_a0 error) *CollectorClient_StreamNetworkPolicies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CollectorClient_StreamNetworkPolicies_Call) RunAndReturn(run func(context.Context, collector.NetworkPolicyIngestor) error) *CollectorClient_StreamNetworkPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StreamNodes provides a mock function with given fields: ctx, ingestor
 func (_m *CollectorClient) StreamNodes(ctx context.Context, ingestor collector.NodeIngestor) error {
 	ret := _m.Called(ctx, ingestor)
@@ -330,6 +416,92 @@ func (_c *CollectorClient_StreamNodes_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// StreamPersistentVolumeClaims provides a mock function with given fields: ctx, ingestor
+func (_m *CollectorClient) StreamPersistentVolumeClaims(ctx context.Context, ingestor collector.PersistentVolumeClaimIngestor) error {
+	ret := _m.Called(ctx, ingestor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, collector.PersistentVolumeClaimIngestor) error); ok {
+		r0 = rf(ctx, ingestor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CollectorClient_StreamPersistentVolumeClaims_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamPersistentVolumeClaims'
+type CollectorClient_StreamPersistentVolumeClaims_Call struct {
+	*mock.Call
+}
+
+// StreamPersistentVolumeClaims is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingestor collector.PersistentVolumeClaimIngestor
+func (_e *CollectorClient_Expecter) StreamPersistentVolumeClaims(ctx interface{}, ingestor interface{}) *CollectorClient_StreamPersistentVolumeClaims_Call {
+	return &CollectorClient_StreamPersistentVolumeClaims_Call{Call: _e.mock.On("StreamPersistentVolumeClaims", ctx, ingestor)}
+}
+
+func (_c *CollectorClient_StreamPersistentVolumeClaims_Call) Run(run func(ctx context.Context, ingestor collector.PersistentVolumeClaimIngestor)) *CollectorClient_StreamPersistentVolumeClaims_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(collector.PersistentVolumeClaimIngestor))
+	})
+	return _c
+}
+
+func (_c *CollectorClient_StreamPersistentVolumeClaims_Call) Return(_a0 error) *CollectorClient_StreamPersistentVolumeClaims_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CollectorClient_StreamPersistentVolumeClaims_Call) RunAndReturn(run func(context.Context, collector.PersistentVolumeClaimIngestor) error) *CollectorClient_StreamPersistentVolumeClaims_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamPersistentVolumes provides a mock function with given fields: ctx, ingestor
+func (_m *CollectorClient) StreamPersistentVolumes(ctx context.Context, ingestor collector.PersistentVolumeIngestor) error {
+	ret := _m.Called(ctx, ingestor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, collector.PersistentVolumeIngestor) error); ok {
+		r0 = rf(ctx, ingestor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CollectorClient_StreamPersistentVolumes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamPersistentVolumes'
+type CollectorClient_StreamPersistentVolumes_Call struct {
+	*mock.Call
+}
+
+// StreamPersistentVolumes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingestor collector.PersistentVolumeIngestor
+func (_e *CollectorClient_Expecter) StreamPersistentVolumes(ctx interface{}, ingestor interface{}) *CollectorClient_StreamPersistentVolumes_Call {
+	return &CollectorClient_StreamPersistentVolumes_Call{Call: _e.mock.On("StreamPersistentVolumes", ctx, ingestor)}
+}
+
+func (_c *CollectorClient_StreamPersistentVolumes_Call) Run(run func(ctx context.Context, ingestor collector.PersistentVolumeIngestor)) *CollectorClient_StreamPersistentVolumes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(collector.PersistentVolumeIngestor))
+	})
+	return _c
+}
+
+func (_c *CollectorClient_StreamPersistentVolumes_Call) Return(_a0 error) *CollectorClient_StreamPersistentVolumes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CollectorClient_StreamPersistentVolumes_Call) RunAndReturn(run func(context.Context, collector.PersistentVolumeIngestor) error) *CollectorClient_StreamPersistentVolumes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StreamPods provides a mock function with given fields: ctx, ingestor
 func (_m *CollectorClient) StreamPods(ctx context.Context, ingestor collector.PodIngestor) error {
 	ret := _m.Called(ctx, ingestor)
@@ -459,6 +631,92 @@ func (_c *CollectorClient_StreamRoles_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// StreamSecrets provides a mock function with given fields: ctx, ingestor
+func (_m *CollectorClient) StreamSecrets(ctx context.Context, ingestor collector.SecretIngestor) error {
+	ret := _m.Called(ctx, ingestor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, collector.SecretIngestor) error); ok {
+		r0 = rf(ctx, ingestor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CollectorClient_StreamSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamSecrets'
+type CollectorClient_StreamSecrets_Call struct {
+	*mock.Call
+}
+
+// StreamSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingestor collector.SecretIngestor
+func (_e *CollectorClient_Expecter) StreamSecrets(ctx interface{}, ingestor interface{}) *CollectorClient_StreamSecrets_Call {
+	return &CollectorClient_StreamSecrets_Call{Call: _e.mock.On("StreamSecrets", ctx, ingestor)}
+}
+
+func (_c *CollectorClient_StreamSecrets_Call) Run(run func(ctx context.Context, ingestor collector.SecretIngestor)) *CollectorClient_StreamSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(collector.SecretIngestor))
+	})
+	return _c
+}
+
+func (_c *CollectorClient_StreamSecrets_Call) Return(_a0 error) *CollectorClient_StreamSecrets_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CollectorClient_StreamSecrets_Call) RunAndReturn(run func(context.Context, collector.SecretIngestor) error) *CollectorClient_StreamSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamServiceAccounts provides a mock function with given fields: ctx, ingestor
+func (_m *CollectorClient) StreamServiceAccounts(ctx context.Context, ingestor collector.ServiceAccountIngestor) error {
+	ret := _m.Called(ctx, ingestor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, collector.ServiceAccountIngestor) error); ok {
+		r0 = rf(ctx, ingestor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CollectorClient_StreamServiceAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamServiceAccounts'
+type CollectorClient_StreamServiceAccounts_Call struct {
+	*mock.Call
+}
+
+// StreamServiceAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingestor collector.ServiceAccountIngestor
+func (_e *CollectorClient_Expecter) StreamServiceAccounts(ctx interface{}, ingestor interface{}) *CollectorClient_StreamServiceAccounts_Call {
+	return &CollectorClient_StreamServiceAccounts_Call{Call: _e.mock.On("StreamServiceAccounts", ctx, ingestor)}
+}
+
+func (_c *CollectorClient_StreamServiceAccounts_Call) Run(run func(ctx context.Context, ingestor collector.ServiceAccountIngestor)) *CollectorClient_StreamServiceAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(collector.ServiceAccountIngestor))
+	})
+	return _c
+}
+
+func (_c *CollectorClient_StreamServiceAccounts_Call) Return(_a0 error) *CollectorClient_StreamServiceAccounts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CollectorClient_StreamServiceAccounts_Call) RunAndReturn(run func(context.Context, collector.ServiceAccountIngestor) error) *CollectorClient_StreamServiceAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewCollectorClient interface {
 	mock.TestingT
 	Cleanup(func())
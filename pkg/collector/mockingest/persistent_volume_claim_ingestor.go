@@ -0,0 +1,123 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/DataDog/KubeHound/pkg/globals/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersistentVolumeClaimIngestor is an autogenerated mock type for the PersistentVolumeClaimIngestor type
+type PersistentVolumeClaimIngestor struct {
+	mock.Mock
+}
+
+type PersistentVolumeClaimIngestor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PersistentVolumeClaimIngestor) EXPECT() *PersistentVolumeClaimIngestor_Expecter {
+	return &PersistentVolumeClaimIngestor_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: _a0
+func (_m *PersistentVolumeClaimIngestor) Complete(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersistentVolumeClaimIngestor_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type PersistentVolumeClaimIngestor_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *PersistentVolumeClaimIngestor_Expecter) Complete(_a0 interface{}) *PersistentVolumeClaimIngestor_Complete_Call {
+	return &PersistentVolumeClaimIngestor_Complete_Call{Call: _e.mock.On("Complete", _a0)}
+}
+
+func (_c *PersistentVolumeClaimIngestor_Complete_Call) Run(run func(_a0 context.Context)) *PersistentVolumeClaimIngestor_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PersistentVolumeClaimIngestor_Complete_Call) Return(_a0 error) *PersistentVolumeClaimIngestor_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersistentVolumeClaimIngestor_Complete_Call) RunAndReturn(run func(context.Context) error) *PersistentVolumeClaimIngestor_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IngestPersistentVolumeClaim provides a mock function with given fields: _a0, _a1
+func (_m *PersistentVolumeClaimIngestor) IngestPersistentVolumeClaim(_a0 context.Context, _a1 types.PersistentVolumeClaimType) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PersistentVolumeClaimType) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IngestPersistentVolumeClaim'
+type PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call struct {
+	*mock.Call
+}
+
+// IngestPersistentVolumeClaim is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 types.PersistentVolumeClaimType
+func (_e *PersistentVolumeClaimIngestor_Expecter) IngestPersistentVolumeClaim(_a0 interface{}, _a1 interface{}) *PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call {
+	return &PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call{Call: _e.mock.On("IngestPersistentVolumeClaim", _a0, _a1)}
+}
+
+func (_c *PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call) Run(run func(_a0 context.Context, _a1 types.PersistentVolumeClaimType)) *PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.PersistentVolumeClaimType))
+	})
+	return _c
+}
+
+func (_c *PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call) Return(_a0 error) *PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call) RunAndReturn(run func(context.Context, types.PersistentVolumeClaimType) error) *PersistentVolumeClaimIngestor_IngestPersistentVolumeClaim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewPersistentVolumeClaimIngestor interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPersistentVolumeClaimIngestor creates a new instance of PersistentVolumeClaimIngestor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPersistentVolumeClaimIngestor(t mockConstructorTestingTNewPersistentVolumeClaimIngestor) *PersistentVolumeClaimIngestor {
+	mock := &PersistentVolumeClaimIngestor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
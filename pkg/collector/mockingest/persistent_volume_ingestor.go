@@ -0,0 +1,123 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/DataDog/KubeHound/pkg/globals/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersistentVolumeIngestor is an autogenerated mock type for the PersistentVolumeIngestor type
+type PersistentVolumeIngestor struct {
+	mock.Mock
+}
+
+type PersistentVolumeIngestor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PersistentVolumeIngestor) EXPECT() *PersistentVolumeIngestor_Expecter {
+	return &PersistentVolumeIngestor_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: _a0
+func (_m *PersistentVolumeIngestor) Complete(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersistentVolumeIngestor_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type PersistentVolumeIngestor_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *PersistentVolumeIngestor_Expecter) Complete(_a0 interface{}) *PersistentVolumeIngestor_Complete_Call {
+	return &PersistentVolumeIngestor_Complete_Call{Call: _e.mock.On("Complete", _a0)}
+}
+
+func (_c *PersistentVolumeIngestor_Complete_Call) Run(run func(_a0 context.Context)) *PersistentVolumeIngestor_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PersistentVolumeIngestor_Complete_Call) Return(_a0 error) *PersistentVolumeIngestor_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersistentVolumeIngestor_Complete_Call) RunAndReturn(run func(context.Context) error) *PersistentVolumeIngestor_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IngestPersistentVolume provides a mock function with given fields: _a0, _a1
+func (_m *PersistentVolumeIngestor) IngestPersistentVolume(_a0 context.Context, _a1 types.PersistentVolumeType) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PersistentVolumeType) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersistentVolumeIngestor_IngestPersistentVolume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IngestPersistentVolume'
+type PersistentVolumeIngestor_IngestPersistentVolume_Call struct {
+	*mock.Call
+}
+
+// IngestPersistentVolume is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 types.PersistentVolumeType
+func (_e *PersistentVolumeIngestor_Expecter) IngestPersistentVolume(_a0 interface{}, _a1 interface{}) *PersistentVolumeIngestor_IngestPersistentVolume_Call {
+	return &PersistentVolumeIngestor_IngestPersistentVolume_Call{Call: _e.mock.On("IngestPersistentVolume", _a0, _a1)}
+}
+
+func (_c *PersistentVolumeIngestor_IngestPersistentVolume_Call) Run(run func(_a0 context.Context, _a1 types.PersistentVolumeType)) *PersistentVolumeIngestor_IngestPersistentVolume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.PersistentVolumeType))
+	})
+	return _c
+}
+
+func (_c *PersistentVolumeIngestor_IngestPersistentVolume_Call) Return(_a0 error) *PersistentVolumeIngestor_IngestPersistentVolume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersistentVolumeIngestor_IngestPersistentVolume_Call) RunAndReturn(run func(context.Context, types.PersistentVolumeType) error) *PersistentVolumeIngestor_IngestPersistentVolume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewPersistentVolumeIngestor interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPersistentVolumeIngestor creates a new instance of PersistentVolumeIngestor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPersistentVolumeIngestor(t mockConstructorTestingTNewPersistentVolumeIngestor) *PersistentVolumeIngestor {
+	mock := &PersistentVolumeIngestor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,123 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/DataDog/KubeHound/pkg/globals/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SecretIngestor is an autogenerated mock type for the SecretIngestor type
+type SecretIngestor struct {
+	mock.Mock
+}
+
+type SecretIngestor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SecretIngestor) EXPECT() *SecretIngestor_Expecter {
+	return &SecretIngestor_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: _a0
+func (_m *SecretIngestor) Complete(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SecretIngestor_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type SecretIngestor_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *SecretIngestor_Expecter) Complete(_a0 interface{}) *SecretIngestor_Complete_Call {
+	return &SecretIngestor_Complete_Call{Call: _e.mock.On("Complete", _a0)}
+}
+
+func (_c *SecretIngestor_Complete_Call) Run(run func(_a0 context.Context)) *SecretIngestor_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SecretIngestor_Complete_Call) Return(_a0 error) *SecretIngestor_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SecretIngestor_Complete_Call) RunAndReturn(run func(context.Context) error) *SecretIngestor_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IngestSecret provides a mock function with given fields: _a0, _a1
+func (_m *SecretIngestor) IngestSecret(_a0 context.Context, _a1 types.SecretType) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.SecretType) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SecretIngestor_IngestSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IngestSecret'
+type SecretIngestor_IngestSecret_Call struct {
+	*mock.Call
+}
+
+// IngestSecret is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 types.SecretType
+func (_e *SecretIngestor_Expecter) IngestSecret(_a0 interface{}, _a1 interface{}) *SecretIngestor_IngestSecret_Call {
+	return &SecretIngestor_IngestSecret_Call{Call: _e.mock.On("IngestSecret", _a0, _a1)}
+}
+
+func (_c *SecretIngestor_IngestSecret_Call) Run(run func(_a0 context.Context, _a1 types.SecretType)) *SecretIngestor_IngestSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.SecretType))
+	})
+	return _c
+}
+
+func (_c *SecretIngestor_IngestSecret_Call) Return(_a0 error) *SecretIngestor_IngestSecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SecretIngestor_IngestSecret_Call) RunAndReturn(run func(context.Context, types.SecretType) error) *SecretIngestor_IngestSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewSecretIngestor interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSecretIngestor creates a new instance of SecretIngestor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSecretIngestor(t mockConstructorTestingTNewSecretIngestor) *SecretIngestor {
+	mock := &SecretIngestor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,123 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/DataDog/KubeHound/pkg/globals/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ServiceAccountIngestor is an autogenerated mock type for the ServiceAccountIngestor type
+type ServiceAccountIngestor struct {
+	mock.Mock
+}
+
+type ServiceAccountIngestor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ServiceAccountIngestor) EXPECT() *ServiceAccountIngestor_Expecter {
+	return &ServiceAccountIngestor_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: _a0
+func (_m *ServiceAccountIngestor) Complete(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceAccountIngestor_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type ServiceAccountIngestor_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *ServiceAccountIngestor_Expecter) Complete(_a0 interface{}) *ServiceAccountIngestor_Complete_Call {
+	return &ServiceAccountIngestor_Complete_Call{Call: _e.mock.On("Complete", _a0)}
+}
+
+func (_c *ServiceAccountIngestor_Complete_Call) Run(run func(_a0 context.Context)) *ServiceAccountIngestor_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ServiceAccountIngestor_Complete_Call) Return(_a0 error) *ServiceAccountIngestor_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ServiceAccountIngestor_Complete_Call) RunAndReturn(run func(context.Context) error) *ServiceAccountIngestor_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IngestServiceAccount provides a mock function with given fields: _a0, _a1
+func (_m *ServiceAccountIngestor) IngestServiceAccount(_a0 context.Context, _a1 types.ServiceAccountType) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.ServiceAccountType) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceAccountIngestor_IngestServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IngestServiceAccount'
+type ServiceAccountIngestor_IngestServiceAccount_Call struct {
+	*mock.Call
+}
+
+// IngestServiceAccount is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 types.ServiceAccountType
+func (_e *ServiceAccountIngestor_Expecter) IngestServiceAccount(_a0 interface{}, _a1 interface{}) *ServiceAccountIngestor_IngestServiceAccount_Call {
+	return &ServiceAccountIngestor_IngestServiceAccount_Call{Call: _e.mock.On("IngestServiceAccount", _a0, _a1)}
+}
+
+func (_c *ServiceAccountIngestor_IngestServiceAccount_Call) Run(run func(_a0 context.Context, _a1 types.ServiceAccountType)) *ServiceAccountIngestor_IngestServiceAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.ServiceAccountType))
+	})
+	return _c
+}
+
+func (_c *ServiceAccountIngestor_IngestServiceAccount_Call) Return(_a0 error) *ServiceAccountIngestor_IngestServiceAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ServiceAccountIngestor_IngestServiceAccount_Call) RunAndReturn(run func(context.Context, types.ServiceAccountType) error) *ServiceAccountIngestor_IngestServiceAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewServiceAccountIngestor interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewServiceAccountIngestor creates a new instance of ServiceAccountIngestor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewServiceAccountIngestor(t mockConstructorTestingTNewServiceAccountIngestor) *ServiceAccountIngestor {
+	mock := &ServiceAccountIngestor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -13,9 +13,10 @@ const (
 
 // CollectorConfig configures collector specific parameters.
 type CollectorConfig struct {
-	Type string                 `mapstructure:"type"` // Collector type
-	File *FileCollectorConfig   `mapstructure:"file"` // File collector specific configuration
-	Live *K8SAPICollectorConfig `mapstructure:"live"` // File collector specific configuration
+	Type    string                 `mapstructure:"type"`    // Collector type
+	Secrets bool                   `mapstructure:"secrets"` // Whether to collect the metadata of secrets (opt-in, values are never collected)
+	File    *FileCollectorConfig   `mapstructure:"file"`    // File collector specific configuration
+	Live    *K8SAPICollectorConfig `mapstructure:"live"`    // File collector specific configuration
 }
 
 // K8SAPICollectorConfig configures the K8sAPI collector.
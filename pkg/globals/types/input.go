@@ -3,6 +3,7 @@ package types
 import (
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
 
@@ -15,11 +16,19 @@ type RoleBindingType *rbacv1.RoleBinding
 type ClusterRoleType *rbacv1.ClusterRole
 type ClusterRoleBindingType *rbacv1.ClusterRoleBinding
 type EndpointType *discoveryv1.EndpointSlice
+type PersistentVolumeType *corev1.PersistentVolume
+type PersistentVolumeClaimType *corev1.PersistentVolumeClaim
+type ServiceAccountType *corev1.ServiceAccount
+type NetworkPolicyType *netv1.NetworkPolicy
+type NamespaceType *corev1.Namespace
+type SecretType *corev1.Secret // Only the metadata and type are collected, values are never populated
 
 type InputType interface {
-	PodType | NodeType | ContainerType | VolumeMountType | RoleType | RoleBindingType | ClusterRoleType | ClusterRoleBindingType | EndpointType
+	PodType | NodeType | ContainerType | VolumeMountType | RoleType | RoleBindingType | ClusterRoleType | ClusterRoleBindingType | EndpointType |
+		PersistentVolumeType | PersistentVolumeClaimType | ServiceAccountType | NetworkPolicyType | NamespaceType | SecretType
 }
 
 type ListInputType interface {
-	corev1.PodList | corev1.NodeList | rbacv1.RoleList | rbacv1.RoleBindingList | rbacv1.ClusterRoleList | rbacv1.ClusterRoleBindingList | discoveryv1.EndpointSliceList
+	corev1.PodList | corev1.NodeList | rbacv1.RoleList | rbacv1.RoleBindingList | rbacv1.ClusterRoleList | rbacv1.ClusterRoleBindingList | discoveryv1.EndpointSliceList |
+		corev1.PersistentVolumeList | corev1.PersistentVolumeClaimList | corev1.ServiceAccountList | netv1.NetworkPolicyList | corev1.NamespaceList | corev1.SecretList
 }
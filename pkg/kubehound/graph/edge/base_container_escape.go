@@ -7,6 +7,7 @@ import (
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -27,6 +28,16 @@ func containerEscapeProcessor(ctx context.Context, oic *converter.ObjectIDConver
 
 	return adapter.GremlinEdgeProcessor(ctx, oic, edgeLabel, typed.Container, typed.Node)
 }
+
+// activeContainerFilter restricts a container filter to containers that can still run code on the node. Completed init
+// containers and exited ephemeral containers are never restarted within the pod lifetime and cannot be used to escape.
+func activeContainerFilter(filter bson.M) bson.M {
+	return bson.M{"$and": bson.A{
+		filter,
+		bson.M{"terminated": bson.M{"$ne": true}},
+	}}
+}
+
 func (e *BaseContainerEscape) Traversal() types.EdgeTraversal {
 	return adapter.DefaultEdgeTraversal()
 }
@@ -0,0 +1,223 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	// Signer issuing client certificates for any subject, honored by the API server
+	CSRSignerClient = "kubernetes.io/kube-apiserver-client"
+
+	// Signer issuing client certificates for node subjects (system:node:<name> in the system:nodes group)
+	CSRSignerKubelet = "kubernetes.io/kube-apiserver-client-kubelet"
+
+	// Group bypassing all authorization checks
+	MastersGroup = "system:masters"
+
+	// Prefix of the dedicated node users (see libkube.NodeUser)
+	NodeUserPrefix = "system:node:"
+)
+
+var (
+	// Request to create certificate signing requests
+	csrCreateRequest = libkube.ResourceRequest{Verb: "create", APIGroup: "certificates.k8s.io",
+		Resource: "certificatesigningrequests"}
+
+	// Request to approve certificate signing requests
+	csrApprovalRequest = libkube.ResourceRequest{Verb: "update", APIGroup: "certificates.k8s.io",
+		Resource: "certificatesigningrequests", Subresource: "approval"}
+
+	// Request matching only fully wildcarded rules i.e cluster-admin equivalent permissions
+	clusterAdminRequest = libkube.ResourceRequest{Verb: "*", APIGroup: "*", Resource: "*"}
+)
+
+func init() {
+	Register(&CSRApprove{}, RegisterDefault)
+}
+
+type CSRApprove struct {
+	BaseEdge
+}
+
+type csrApproveGroup struct {
+	Identity primitive.ObjectID `bson:"identity" json:"identity"`
+	Target   primitive.ObjectID `bson:"target" json:"target"`
+}
+
+// identityBinding is a cluster wide permission set bound to an identity.
+type identityBinding struct {
+	Identity primitive.ObjectID  `bson:"identity_id"`
+	Rules    []rbacv1.PolicyRule `bson:"rules"`
+}
+
+func (e *CSRApprove) Label() string {
+	return "CSR_APPROVE"
+}
+
+func (e *CSRApprove) Name() string {
+	return "CSRApprove"
+}
+
+func (e *CSRApprove) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*csrApproveGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Identity, typed.Target)
+}
+
+// signerApproveAllowed returns whether the rules grant the approval of certificates for the provided signer. The
+// approval is checked against the signer name and the signer domain wildcard, as done by the K8s API server.
+// See reference for details: https://kubernetes.io/docs/reference/access-authn-authz/certificate-signing-requests/#authorization
+func signerApproveAllowed(rules []rbacv1.PolicyRule, signer string) bool {
+	domain, _, _ := strings.Cut(signer, "/")
+
+	return rulesAllowAny(rules,
+		libkube.ResourceRequest{Verb: "approve", APIGroup: "certificates.k8s.io", Resource: "signers", Name: signer},
+		libkube.ResourceRequest{Verb: "approve", APIGroup: "certificates.k8s.io", Resource: "signers", Name: domain + "/*"},
+	)
+}
+
+// streamIdentityBindings returns the rules of all cluster wide permission sets, indexed by bound identity.
+// Certificate signing requests are not namespaced and as such namespaced permission sets are not considered.
+func (e *CSRApprove) streamIdentityBindings(ctx context.Context, store storedb.Provider) (map[primitive.ObjectID][]rbacv1.PolicyRule, error) {
+	permissionSets := adapter.MongoDB(store).Collection(collections.PermissionSetName)
+
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"is_namespaced": false,
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"as":           "roleBinding",
+				"from":         collections.RoleBindingName,
+				"localField":   "role_binding_id",
+				"foreignField": "_id",
+			},
+		},
+		{
+			"$unwind": "$roleBinding",
+		},
+		{
+			"$unwind": "$roleBinding.subjects",
+		},
+		{
+			"$project": bson.M{
+				"_id":         0,
+				"identity_id": "$roleBinding.subjects.identity_id",
+				"rules":       1,
+			},
+		},
+	}
+
+	cur, err := permissionSets.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	bindings := make(map[primitive.ObjectID][]rbacv1.PolicyRule)
+	for cur.Next(ctx) {
+		var entry identityBinding
+		err := cur.Decode(&entry)
+		if err != nil {
+			return nil, err
+		}
+
+		bindings[entry.Identity] = append(bindings[entry.Identity], entry.Rules...)
+	}
+
+	return bindings, cur.Err()
+}
+
+// Stream finds all identities that can both create and approve certificate signing requests, combining the cluster
+// wide permission sets bound to the identity. Such identities can mint client certificates for the node identities
+// (via either client signer) and for the system:masters group or any cluster-admin equivalent identity (via the generic
+// API server client signer).
+func (e *CSRApprove) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	bindings, err := e.streamIdentityBindings(ctx, store)
+	if err != nil {
+		return err
+	}
+
+	nodeFilter := bson.M{"$or": bson.A{
+		bson.M{"type": "User", "name": bson.M{"$regex": "^" + regexp.QuoteMeta(NodeUserPrefix)}},
+		bson.M{"type": "Group", "name": libkube.DefaultNodeGroup},
+	}}
+
+	criticalIdentities := bson.A{}
+	for identity, rules := range bindings {
+		if libkube.RulesAllow(rules, clusterAdminRequest) {
+			criticalIdentities = append(criticalIdentities, identity)
+		}
+	}
+
+	// Critical identities that are also node identities are already covered by the node targets
+	criticalFilter := bson.M{
+		"$or": bson.A{
+			bson.M{"type": "Group", "name": MastersGroup},
+			bson.M{"_id": bson.M{"$in": criticalIdentities}},
+		},
+		"$nor": bson.A{nodeFilter},
+	}
+
+	// The targets are shared by all identities, so they are loaded once rather than queried per identity
+	nodeTargets, err := loadTargets(ctx, store, collections.IdentityName, nodeFilter)
+	if err != nil {
+		return err
+	}
+
+	criticalTargets, err := loadTargets(ctx, store, collections.IdentityName, criticalFilter)
+	if err != nil {
+		return err
+	}
+
+	for identity, rules := range bindings {
+		if !libkube.RulesAllow(rules, csrCreateRequest) || !libkube.RulesAllow(rules, csrApprovalRequest) {
+			continue
+		}
+
+		clientSigner := signerApproveAllowed(rules, CSRSignerClient)
+		if !clientSigner && !signerApproveAllowed(rules, CSRSignerKubelet) {
+			continue
+		}
+
+		targets := nodeTargets
+		if clientSigner {
+			targets = append(append([]primitive.ObjectID{}, nodeTargets...), criticalTargets...)
+		}
+
+		for _, target := range targets {
+			// Exclude the source identity as it gains nothing from impersonating itself
+			if target == identity {
+				continue
+			}
+
+			err := callback(ctx, &csrApproveGroup{Identity: identity, Target: target})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return complete(ctx)
+}
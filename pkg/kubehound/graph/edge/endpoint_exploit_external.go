@@ -25,6 +25,11 @@ type EndpointExploitExternal struct {
 type sliceEndpointGroup struct {
 	Endpoint  primitive.ObjectID `bson:"_id" json:"endpoint_id"`
 	Container primitive.ObjectID `bson:"container_id" json:"container_id"`
+	Ingress   endpointIngress    `bson:",inline" json:"ingress"`
+}
+
+func (g *sliceEndpointGroup) ingress() *endpointIngress {
+	return &g.Ingress
 }
 
 func (e *EndpointExploitExternal) Label() string {
@@ -41,12 +46,17 @@ func (e *EndpointExploitExternal) Processor(ctx context.Context, oic *converter.
 		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
 	}
 
-	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Endpoint, typed.Container)
+	return endpointExploitProcessor(ctx, oic, e.Label(), typed.Endpoint, typed.Container, &typed.Ingress)
 }
 
 func (e *EndpointExploitExternal) Stream(ctx context.Context, store storedb.Provider, c cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
+	reachability, err := newEndpointReachability(ctx, store)
+	if err != nil {
+		return err
+	}
+
 	endpoints := adapter.MongoDB(store).Collection(collections.EndpointName)
 
 	// K8s endpoint slices must be ingested before containers. In this stage we need to match store.Endpoint documents that
@@ -116,18 +126,20 @@ func (e *EndpointExploitExternal) Stream(ctx context.Context, store storedb.Prov
 			"$unwind": "$matchContainers",
 		},
 		{
-			"$project": bson.M{
-				"_id":          1,
+			"$addFields": bson.M{
 				"container_id": "$matchContainers._id",
 			},
 		},
 	}
 
+	// Add the pod labels and port details to evaluate the ingress network policies of the pod
+	pipeline = append(pipeline, endpointIngressStages()...)
+
 	cur, err := endpoints.Aggregate(context.Background(), pipeline)
 	if err != nil {
 		return err
 	}
 	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[sliceEndpointGroup](ctx, cur, callback, complete)
+	return adapter.MongoCursorHandler[sliceEndpointGroup](ctx, cur, reachability.Callback(callback), complete)
 }
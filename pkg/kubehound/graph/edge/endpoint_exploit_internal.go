@@ -12,7 +12,6 @@ import (
 	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func init() {
@@ -26,6 +25,11 @@ type EndpointExploitInternal struct {
 type containerEndpointGroup struct {
 	Endpoint  primitive.ObjectID `bson:"_id" json:"endpoint_id"`
 	Container primitive.ObjectID `bson:"container_id" json:"container_id"`
+	Ingress   endpointIngress    `bson:",inline" json:"ingress"`
+}
+
+func (g *containerEndpointGroup) ingress() *endpointIngress {
+	return &g.Ingress
 }
 
 func (e *EndpointExploitInternal) Label() string {
@@ -42,28 +46,37 @@ func (e *EndpointExploitInternal) Processor(ctx context.Context, oic *converter.
 		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
 	}
 
-	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Endpoint, typed.Container)
+	return endpointExploitProcessor(ctx, oic, e.Label(), typed.Endpoint, typed.Container, &typed.Ingress)
 }
 
 func (e *EndpointExploitInternal) Stream(ctx context.Context, store storedb.Provider, c cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
+	reachability, err := newEndpointReachability(ctx, store)
+	if err != nil {
+		return err
+	}
+
 	endpoints := adapter.MongoDB(store).Collection(collections.EndpointName)
 
 	// Collect the endpoints with no associated slice. These are directly created from a container port in the
 	// pod ingest pipeline and so already have an associated container ID we can use directly.
-	filter := bson.M{
-		"has_slice": false,
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"has_slice": false,
+			},
+		},
 	}
 
-	// We just need a 1:1 mapping of the (private) endpoint and container to create this edge
-	projection := bson.M{"_id": 1, "container_id": 1}
+	// Add the pod labels and port details to evaluate the ingress network policies of the pod
+	pipeline = append(pipeline, endpointIngressStages()...)
 
-	cur, err := endpoints.Find(context.Background(), filter, options.Find().SetProjection(projection))
+	cur, err := endpoints.Aggregate(context.Background(), pipeline)
 	if err != nil {
 		return err
 	}
 	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[containerEndpointGroup](ctx, cur, callback, complete)
+	return adapter.MongoCursorHandler[containerEndpointGroup](ctx, cur, reachability.Callback(callback), complete)
 }
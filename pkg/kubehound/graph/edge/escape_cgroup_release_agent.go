@@ -0,0 +1,64 @@
+package edge
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(&EscapeCgroupReleaseAgent{}, RegisterDefault)
+}
+
+type EscapeCgroupReleaseAgent struct {
+	BaseContainerEscape
+}
+
+func (e *EscapeCgroupReleaseAgent) Label() string {
+	return "CE_CGROUP_RELEASE_AGENT"
+}
+
+func (e *EscapeCgroupReleaseAgent) Name() string {
+	return "ContainerEscapeCgroupReleaseAgent"
+}
+
+// Processor delegates the processing tasks to to the generic containerEscapeProcessor.
+func (e *EscapeCgroupReleaseAgent) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	return containerEscapeProcessor(ctx, oic, e.Label(), entry)
+}
+
+func (e *EscapeCgroupReleaseAgent) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	containers := adapter.MongoDB(store).Collection(collections.ContainerName)
+
+	// Escape is possible with CAP_SYS_ADMIN loaded explicitly, provided the AppArmor profile explicitly set on the
+	// container does not block the mount syscall. Unset profiles are left to the container runtime and the node
+	// configuration, which we cannot observe, so these containers are considered unconfined. The runtime default
+	// seccomp profiles allow mount when CAP_SYS_ADMIN is granted, so only custom (Localhost) profiles are excluded.
+	filter := bson.M{
+		"k8.securitycontext.capabilities.add": bson.M{"$in": bson.A{"SYS_ADMIN", "CAP_SYS_ADMIN"}},
+		"inherited.apparmor":                  bson.M{"$in": bson.A{nil, "", libkube.AppArmorUnconfined}},
+		"inherited.seccomp":                   bson.M{"$ne": string(corev1.SeccompProfileTypeLocalhost)},
+	}
+
+	// We just need a 1:1 mapping of the node and container to create this edge
+	projection := bson.M{"_id": 1, "node_id": 1}
+
+	cur, err := containers.Find(context.Background(), activeContainerFilter(filter), options.Find().SetProjection(projection))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return adapter.MongoCursorHandler[containerEscapeGroup](ctx, cur, callback, complete)
+}
@@ -0,0 +1,58 @@
+package edge
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func init() {
+	Register(&EscapeDacReadSearch{}, RegisterDefault)
+}
+
+type EscapeDacReadSearch struct {
+	BaseContainerEscape
+}
+
+func (e *EscapeDacReadSearch) Label() string {
+	return "CE_DAC_READ_SEARCH"
+}
+
+func (e *EscapeDacReadSearch) Name() string {
+	return "ContainerEscapeDacReadSearch"
+}
+
+// Processor delegates the processing tasks to to the generic containerEscapeProcessor.
+func (e *EscapeDacReadSearch) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	return containerEscapeProcessor(ctx, oic, e.Label(), entry)
+}
+
+func (e *EscapeDacReadSearch) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	containers := adapter.MongoDB(store).Collection(collections.ContainerName)
+
+	// Arbitrary host file read is possible with CAP_DAC_READ_SEARCH loaded explicitly, via open_by_handle_at. This is
+	// not blocked by the runtime default seccomp profiles which allow the syscall when the capability is granted.
+	filter := bson.M{
+		"k8.securitycontext.capabilities.add": bson.M{"$in": bson.A{"DAC_READ_SEARCH", "CAP_DAC_READ_SEARCH"}},
+	}
+
+	// We just need a 1:1 mapping of the node and container to create this edge
+	projection := bson.M{"_id": 1, "node_id": 1}
+
+	cur, err := containers.Find(context.Background(), activeContainerFilter(filter), options.Find().SetProjection(projection))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return adapter.MongoCursorHandler[containerEscapeGroup](ctx, cur, callback, complete)
+}
@@ -0,0 +1,124 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Capabilities granting raw access to the network stack (packet capture, ARP spoofing, routing changes, etc)
+var NetMitmCapabilityList = []string{
+	"NET_RAW",
+	"CAP_NET_RAW",
+	"NET_ADMIN",
+	"CAP_NET_ADMIN",
+}
+
+// Dropped capabilities removing NET_RAW, which container runtimes otherwise grant by default
+var NetMitmDefaultDropList = []string{
+	"NET_RAW",
+	"CAP_NET_RAW",
+	"ALL",
+}
+
+func init() {
+	Register(&EscapeNetMitm{}, RegisterDefault)
+}
+
+type EscapeNetMitm struct {
+	BaseEdge
+}
+
+type escapeNetMitmGroup struct {
+	Container primitive.ObjectID `bson:"_id" json:"container"`
+	Target    primitive.ObjectID `bson:"target_id" json:"target"`
+}
+
+func (e *EscapeNetMitm) Label() string {
+	return "CE_NET_MITM"
+}
+
+func (e *EscapeNetMitm) Name() string {
+	return "ContainerEscapeNetMitm"
+}
+
+func (e *EscapeNetMitm) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*escapeNetMitmGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Container, typed.Target)
+}
+
+// Stream finds all containers sharing the host network namespace with NET_RAW/NET_ADMIN capabilities (or privileged)
+// and links them to every other container on the same node, whose traffic transits through the node network stack.
+// Since NET_RAW is part of the default capability set of container runtimes, containers that do not explicitly drop it
+// (or ALL capabilities) are matched as well.
+func (e *EscapeNetMitm) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	containers := adapter.MongoDB(store).Collection(collections.ContainerName)
+
+	pipeline := []bson.M{
+		{
+			"$match": activeContainerFilter(bson.M{"$and": bson.A{
+				bson.M{"inherited.host_net": true},
+				bson.M{"$or": bson.A{
+					bson.M{"k8.securitycontext.privileged": true},
+					bson.M{"k8.securitycontext.capabilities.add": bson.M{"$in": NetMitmCapabilityList}},
+					bson.M{"k8.securitycontext.capabilities.drop": bson.M{"$nin": NetMitmDefaultDropList}},
+				}},
+			}}),
+		},
+		{
+			"$lookup": bson.M{
+				"as":   "targets",
+				"from": collections.ContainerName,
+				"let": bson.M{
+					"cid": "$_id",
+					"nid": "$node_id",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+							bson.M{"$eq": bson.A{"$node_id", "$$nid"}},
+							bson.M{"$ne": bson.A{"$_id", "$$cid"}},
+							bson.M{"$ne": bson.A{"$terminated", true}},
+						}}},
+					},
+					{
+						"$project": bson.M{
+							"_id": 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$unwind": "$targets",
+		},
+		{
+			"$project": bson.M{
+				"_id":       1,
+				"target_id": "$targets._id",
+			},
+		},
+	}
+
+	cur, err := containers.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return adapter.MongoCursorHandler[escapeNetMitmGroup](ctx, cur, callback, complete)
+}
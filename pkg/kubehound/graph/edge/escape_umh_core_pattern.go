@@ -0,0 +1,104 @@
+package edge
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mounts that grant write access to the host /proc/sys/kernel/core_pattern file
+var CorePatternMountList = []primitive.Regex{
+	{Pattern: "^/proc$"},
+	{Pattern: "^/proc/sys$"},
+	{Pattern: "^/proc/sys/kernel$"},
+	{Pattern: "^/proc/sys/kernel/core_pattern$"},
+}
+
+func init() {
+	Register(&EscapeCorePattern{}, RegisterDefault)
+}
+
+type EscapeCorePattern struct {
+	BaseContainerEscape
+}
+
+func (e *EscapeCorePattern) Label() string {
+	return "CE_UMH_CORE_PATTERN"
+}
+
+func (e *EscapeCorePattern) Name() string {
+	return "ContainerEscapeCorePattern"
+}
+
+// Processor delegates the processing tasks to to the generic containerEscapeProcessor.
+func (e *EscapeCorePattern) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	return containerEscapeProcessor(ctx, oic, e.Label(), entry)
+}
+
+func (e *EscapeCorePattern) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	containers := adapter.MongoDB(store).Collection(collections.ContainerName)
+
+	// Escape is possible with privileged containers (where /proc/sys is not mounted read only) or containers with
+	// a writable host mount of the core_pattern file or any of its parent directories
+	pipeline := []bson.M{
+		{
+			"$lookup": bson.M{
+				"as":   "procMounts",
+				"from": collections.VolumeName,
+				"let": bson.M{
+					"cid": "$_id",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$and": bson.A{
+							bson.M{"$expr": bson.M{
+								"$eq": bson.A{
+									"$container_id", "$$cid",
+								},
+							}},
+							bson.M{"type": shared.VolumeTypeHost},
+							bson.M{"readonly": false},
+							bson.M{"source": bson.M{"$in": CorePatternMountList}},
+						}},
+					},
+					{
+						"$project": bson.M{
+							"_id": 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$match": activeContainerFilter(bson.M{"$or": bson.A{
+				bson.M{"k8.securitycontext.privileged": true},
+				bson.M{"procMounts": bson.M{"$ne": bson.A{}}},
+			}}),
+		},
+		{
+			// We just need a 1:1 mapping of the node and container to create this edge
+			"$project": bson.M{
+				"_id":     1,
+				"node_id": 1,
+			},
+		},
+	}
+
+	cur, err := containers.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return adapter.MongoCursorHandler[containerEscapeGroup](ctx, cur, callback, complete)
+}
@@ -0,0 +1,123 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mounts that expose a container runtime socket (or any parent directory of one). As /var/run is typically a symlink
+// to /run both variants are considered.
+var ContainerRuntimeSockMountList = []primitive.Regex{
+	{Pattern: "^/$"},
+	{Pattern: "^/run$"},
+	{Pattern: "^/var$"},
+	{Pattern: "^/var/run$"},
+	{Pattern: "^(/var)?/run/containerd$"},
+	{Pattern: "^(/var)?/run/containerd/containerd\\.sock$"},
+	{Pattern: "^(/var)?/run/crio$"},
+	{Pattern: "^(/var)?/run/crio/crio\\.sock$"},
+	{Pattern: "^(/var)?/run/docker\\.sock$"},
+	{Pattern: "^(/var)?/run/dockershim\\.sock$"},
+	{Pattern: "^(/var)?/run/cri-dockerd\\.sock$"},
+}
+
+func init() {
+	Register(&ExploitContainerdSock{}, RegisterDefault)
+}
+
+type ExploitContainerdSock struct {
+	BaseEdge
+}
+
+type exploitContainerdSockGroup struct {
+	Container primitive.ObjectID `bson:"container_id" json:"container"`
+	Node      primitive.ObjectID `bson:"node_id" json:"node"`
+}
+
+func (e *ExploitContainerdSock) Label() string {
+	return "EXPLOIT_CONTAINERD_SOCK"
+}
+
+func (e *ExploitContainerdSock) Name() string {
+	return "ExploitContainerdSock"
+}
+
+func (e *ExploitContainerdSock) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*exploitContainerdSockGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Container, typed.Node)
+}
+
+func (e *ExploitContainerdSock) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	volumes := adapter.MongoDB(store).Collection(collections.VolumeName)
+
+	// Escape is possible if the container runtime socket is mounted into the container. Read only mounts offer no
+	// protection as the socket can still be connected to, so we consider all host mounts of the socket or its parents.
+	filter := bson.M{
+		"type": shared.VolumeTypeHost,
+		"source": bson.M{
+			"$in": ContainerRuntimeSockMountList,
+		},
+	}
+
+	pipeline := []bson.M{
+		{
+			"$match": filter,
+		},
+		// Terminated init and ephemeral containers can no longer connect to the socket
+		{
+			"$lookup": bson.M{
+				"as":           "container",
+				"from":         collections.ContainerName,
+				"localField":   "container_id",
+				"foreignField": "_id",
+			},
+		},
+		{
+			"$match": bson.M{
+				"container.terminated": bson.M{"$ne": true},
+			},
+		},
+		{
+			// A container may mount several matching paths (e.g / and /run/containerd), but only a single edge can be
+			// created between the container and its node
+			"$group": bson.M{
+				"_id": bson.M{
+					"container_id": "$container_id",
+					"node_id":      "$node_id",
+				},
+			},
+		},
+		{
+			// We just need a 1:1 mapping of the container and node to create this edge
+			"$project": bson.M{
+				"_id":          0,
+				"container_id": "$_id.container_id",
+				"node_id":      "$_id.node_id",
+			},
+		},
+	}
+
+	cur, err := volumes.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return adapter.MongoCursorHandler[exploitContainerdSockGroup](ctx, cur, callback, complete)
+}
@@ -0,0 +1,115 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func init() {
+	Register(&IdentityAssumeCloud{}, RegisterDefault)
+}
+
+type IdentityAssumeCloud struct {
+	BaseEdge
+}
+
+type cloudIdentityGroup struct {
+	CloudIdentity primitive.ObjectID `bson:"canonical_id" json:"cloud_identity"`
+	Identity      primitive.ObjectID `bson:"identity_id" json:"identity"`
+}
+
+func (e *IdentityAssumeCloud) Label() string {
+	return "IDENTITY_ASSUME_CLOUD"
+}
+
+func (e *IdentityAssumeCloud) Name() string {
+	return "IdentityAssumeCloud"
+}
+
+func (e *IdentityAssumeCloud) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*cloudIdentityGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Identity, typed.CloudIdentity)
+}
+
+// Stream finds all the cloud workload identities and matches them to the identity of the service account they are
+// bound to via its annotations (EKS IRSA, GKE workload identity or Azure workload identity). A cloud identity bound to
+// several service accounts has one entry per binding, all sharing the canonical id of the cloud identity vertex.
+func (e *IdentityAssumeCloud) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	cloudIdentities := adapter.MongoDB(store).Collection(collections.CloudIdentityName)
+
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"type": shared.CloudIdentityTypeWorkload,
+			},
+		},
+		{
+			// Lookup the identity of the bound service account. This requires a match on namespace/name/type
+			"$lookup": bson.M{
+				"as":   "identity",
+				"from": collections.IdentityName,
+				"let": bson.M{
+					"sa":   "$service_account",
+					"saNS": "$namespace",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$expr": bson.M{
+							"$and": bson.A{
+								bson.M{"$eq": bson.A{
+									"$name", "$$sa",
+								}},
+								bson.M{"$eq": bson.A{
+									"$namespace", "$$saNS",
+								}},
+								bson.M{"$eq": bson.A{
+									"$type", rbacv1.ServiceAccountKind,
+								}},
+							},
+						}},
+					},
+					{
+						"$project": bson.M{
+							"_id": 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$unwind": "$identity",
+		},
+		{
+			"$project": bson.M{
+				"_id":          0,
+				"canonical_id": 1,
+				"identity_id":  "$identity._id",
+			},
+		},
+	}
+
+	cur, err := cloudIdentities.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return adapter.MongoCursorHandler[cloudIdentityGroup](ctx, cur, callback, complete)
+}
@@ -0,0 +1,67 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func init() {
+	Register(&IdentityAssumeCloudNode{}, RegisterDefault)
+}
+
+type IdentityAssumeCloudNode struct {
+	BaseEdge
+}
+
+type nodeCloudIdentityGroup struct {
+	CloudIdentity primitive.ObjectID `bson:"canonical_id" json:"cloud_identity"`
+	Node          primitive.ObjectID `bson:"node_id" json:"node"`
+}
+
+func (e *IdentityAssumeCloudNode) Label() string {
+	return "IDENTITY_ASSUME_CLOUD"
+}
+
+func (e *IdentityAssumeCloudNode) Name() string {
+	return "IdentityAssumeCloudNode"
+}
+
+func (e *IdentityAssumeCloudNode) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*nodeCloudIdentityGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Node, typed.CloudIdentity)
+}
+
+func (e *IdentityAssumeCloudNode) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	cloudIdentities := adapter.MongoDB(store).Collection(collections.CloudIdentityName)
+
+	// Instance identities are created from the node provider ID, so we just need a 1:1 mapping of the node and
+	// cloud identity entry, pointing to the canonical cloud identity vertex. The instance metadata service exposes the
+	// credentials of the instance role to the node.
+	filter := bson.M{"type": shared.CloudIdentityTypeInstance}
+	projection := bson.M{"_id": 0, "canonical_id": 1, "node_id": 1}
+
+	cur, err := cloudIdentities.Find(context.Background(), filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return adapter.MongoCursorHandler[nodeCloudIdentityGroup](ctx, cur, callback, complete)
+}
@@ -0,0 +1,114 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func init() {
+	Register(&IdentityAssumeSecret{}, RegisterDefault)
+}
+
+type IdentityAssumeSecret struct {
+	BaseEdge
+}
+
+type secretIdentityGroup struct {
+	Secret   primitive.ObjectID `bson:"_id" json:"secret"`
+	Identity primitive.ObjectID `bson:"identity_id" json:"identity"`
+}
+
+func (e *IdentityAssumeSecret) Label() string {
+	return "IDENTITY_ASSUME"
+}
+
+func (e *IdentityAssumeSecret) Name() string {
+	return "IdentityAssumeSecret"
+}
+
+func (e *IdentityAssumeSecret) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*secretIdentityGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Secret, typed.Identity)
+}
+
+// Stream finds all the service account token secrets and matches them to the identity of the service account they
+// authenticate as, via the kubernetes.io/service-account.name annotation.
+func (e *IdentityAssumeSecret) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	secrets := adapter.MongoDB(store).Collection(collections.SecretName)
+
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"type":            string(corev1.SecretTypeServiceAccountToken),
+				"service_account": bson.M{"$ne": ""},
+			},
+		},
+		{
+			// Lookup the identity of the token service account. This requires a match on namespace/name/type
+			"$lookup": bson.M{
+				"as":   "identity",
+				"from": collections.IdentityName,
+				"let": bson.M{
+					"sa":   "$service_account",
+					"saNS": "$namespace",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$expr": bson.M{
+							"$and": bson.A{
+								bson.M{"$eq": bson.A{
+									"$name", "$$sa",
+								}},
+								bson.M{"$eq": bson.A{
+									"$namespace", "$$saNS",
+								}},
+								bson.M{"$eq": bson.A{
+									"$type", rbacv1.ServiceAccountKind,
+								}},
+							},
+						}},
+					},
+					{
+						"$project": bson.M{
+							"_id": 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$unwind": "$identity",
+		},
+		{
+			"$project": bson.M{
+				"_id":         1,
+				"identity_id": "$identity._id",
+			},
+		},
+	}
+
+	cur, err := secrets.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return adapter.MongoCursorHandler[secretIdentityGroup](ctx, cur, callback, complete)
+}
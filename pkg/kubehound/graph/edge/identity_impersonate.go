@@ -0,0 +1,120 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Identity types that can be impersonated along with their impersonation resource
+var impersonationResources = []struct {
+	identityType string
+	resource     string
+	namespaced   bool
+}{
+	{identityType: "User", resource: "users", namespaced: false},
+	{identityType: "Group", resource: "groups", namespaced: false},
+	{identityType: "ServiceAccount", resource: "serviceaccounts", namespaced: true},
+}
+
+func init() {
+	Register(&IdentityImpersonate{}, RegisterDefault)
+}
+
+type IdentityImpersonate struct {
+	BaseEdge
+}
+
+type identityImpersonateGroup struct {
+	Role     primitive.ObjectID `bson:"role" json:"role"`
+	Identity primitive.ObjectID `bson:"identity" json:"identity"`
+}
+
+func (e *IdentityImpersonate) Label() string {
+	return "IDENTITY_IMPERSONATE"
+}
+
+func (e *IdentityImpersonate) Name() string {
+	return "IdentityImpersonate"
+}
+
+func (e *IdentityImpersonate) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*identityImpersonateGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Identity)
+}
+
+// Stream finds all roles that are NOT namespaced and have users/groups/serviceaccounts impersonate or equivalent wildcard
+// permissions and matching identities. Matching identities are defined as all identities of the impersonated type,
+// restricted to the rule resource names if present.
+func (e *IdentityImpersonate) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	filter := bson.M{
+		"is_namespaced": false,
+	}
+
+	err := streamImpersonatedIdentities(ctx, store, filter, callback)
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
+
+// streamImpersonatedIdentities invokes the callback on all identities the permission sets matching the filter can
+// impersonate. Namespaced permission sets can only grant impersonation of service accounts within their own namespace
+// as users and groups are cluster scoped resources. The uids and userextras resources (authentication.k8s.io) only
+// supplement a user impersonation and as such cannot be used to assume a new identity on their own.
+func streamImpersonatedIdentities(ctx context.Context, store storedb.Provider, filter bson.M,
+	callback types.ProcessEntryCallback) error {
+
+	requests := make([]libkube.ResourceRequest, 0, len(impersonationResources))
+	batches := make([]*targetBatch, 0, len(impersonationResources))
+	for _, imp := range impersonationResources {
+		requests = append(requests, libkube.ResourceRequest{Verb: "impersonate", APIGroup: "", Resource: imp.resource})
+		batches = append(batches, newTargetBatch(collections.IdentityName, bson.M{"type": imp.identityType},
+			"namespace", "name", false))
+	}
+
+	err := streamPermissionSets(ctx, store, filter, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		for i, imp := range impersonationResources {
+			if ps.IsNamespaced && !imp.namespaced {
+				continue
+			}
+
+			scope := libkube.RulesResourceScope(ps.Rules, requests[i])
+			if scope.Allowed() {
+				batches[i].Add(ps, scope)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		err := batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, identity primitive.ObjectID) error {
+			return callback(ctx, &identityImpersonateGroup{Role: role, Identity: identity})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
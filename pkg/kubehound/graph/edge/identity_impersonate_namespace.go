@@ -0,0 +1,56 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func init() {
+	Register(&IdentityImpersonateNamespace{}, RegisterDefault)
+}
+
+type IdentityImpersonateNamespace struct {
+	BaseEdge
+}
+
+func (e *IdentityImpersonateNamespace) Label() string {
+	return "IDENTITY_IMPERSONATE"
+}
+
+func (e *IdentityImpersonateNamespace) Name() string {
+	return "IdentityImpersonateNamespace"
+}
+
+func (e *IdentityImpersonateNamespace) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*identityImpersonateGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Identity)
+}
+
+// Stream finds all roles that are namespaced and have serviceaccounts impersonate or equivalent wildcard permissions
+// and matching identities. Matching identities are defined as service accounts that share the role namespace,
+// restricted to the rule resource names if present.
+func (e *IdentityImpersonateNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	filter := bson.M{
+		"is_namespaced": true,
+	}
+
+	err := streamImpersonatedIdentities(ctx, store, filter, callback)
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -0,0 +1,263 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// endpointIngress holds the attributes of an endpoint required to evaluate the ingress network policies of its pod.
+type endpointIngress struct {
+	PodNamespace string            `bson:"pod_namespace" json:"pod_namespace"`
+	PodLabels    map[string]string `bson:"pod_labels" json:"pod_labels"`
+	Port         int32             `bson:"port" json:"port"`
+	PortName     string            `bson:"port_name" json:"port_name"`
+	Protocol     string            `bson:"protocol" json:"protocol"`
+	Reachable    bool              `bson:"-" json:"reachable"`
+}
+
+// endpointIngressEntry is implemented by the edge query results holding an endpointIngress.
+type endpointIngressEntry interface {
+	ingress() *endpointIngress
+}
+
+// policyPort returns the endpoint port in the format expected by the network policy evaluation.
+func (e *endpointIngress) policyPort() libkube.PolicyPort {
+	protocol := e.Protocol
+	if protocol == "" {
+		protocol = store.DefaultEndpointProtocol
+	}
+
+	return libkube.PolicyPort{
+		Port:     e.Port,
+		Name:     e.PortName,
+		Protocol: protocol,
+	}
+}
+
+// endpointIngressStages returns the aggregation stages to project an endpoint document (with a container_id field) to
+// the fields of an endpointIngress, looking up the labels of the pod exposing the endpoint.
+func endpointIngressStages() []bson.M {
+	return []bson.M{
+		{
+			"$lookup": bson.M{
+				"as":   "ingressPod",
+				"from": collections.PodName,
+				"let": bson.M{
+					"pod":   "$pod_name",
+					"podNS": "$pod_namespace",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$expr": bson.M{
+							"$and": bson.A{
+								bson.M{"$eq": bson.A{
+									"$k8.objectmeta.namespace", "$$podNS",
+								}},
+								bson.M{"$eq": bson.A{
+									"$k8.objectmeta.name", "$$pod",
+								}},
+							},
+						}},
+					},
+					{
+						"$project": bson.M{
+							"labels": "$k8.objectmeta.labels",
+						},
+					},
+				},
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id":           1,
+				"container_id":  1,
+				"pod_namespace": 1,
+				"pod_labels":    bson.M{"$arrayElemAt": bson.A{"$ingressPod.labels", 0}},
+				"port":          "$port.port",
+				"port_name":     "$port.name",
+				"protocol":      "$port.protocol",
+			},
+		},
+	}
+}
+
+// endpointReachability evaluates the ingress network policies of the cluster against the endpoints.
+type endpointReachability struct {
+	policies map[string][]netv1.NetworkPolicySpec // Ingress policy specs indexed by namespace
+	sources  []libkube.PolicySource               // Pods of the cluster, as potential sources of the ingress traffic
+	results  map[string]bool                      // Reachability results indexed by namespace, pod labels and port
+}
+
+// newEndpointReachability loads all the ingress network policies from the store, along with the labels of the pods and
+// namespaces of the cluster the policy peers are evaluated against. The number of network policies and pods is
+// expected to be small enough to be held in memory.
+func newEndpointReachability(ctx context.Context, sp storedb.Provider) (*endpointReachability, error) {
+	r := &endpointReachability{
+		policies: make(map[string][]netv1.NetworkPolicySpec),
+		results:  make(map[string]bool),
+	}
+
+	err := r.loadPolicies(ctx, sp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without any policy, all endpoints are reachable and the pods need not be loaded
+	if len(r.policies) == 0 {
+		return r, nil
+	}
+
+	err = r.loadSources(ctx, sp)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// loadPolicies loads all the network policies from the store, indexed by namespace.
+func (r *endpointReachability) loadPolicies(ctx context.Context, sp storedb.Provider) error {
+	policies := adapter.MongoDB(sp).Collection(collections.NetworkPolicyName)
+
+	cur, err := policies.Find(context.Background(), bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var np store.NetworkPolicy
+		err := cur.Decode(&np)
+		if err != nil {
+			return err
+		}
+
+		r.policies[np.Namespace] = append(r.policies[np.Namespace], np.K8)
+	}
+
+	return cur.Err()
+}
+
+// loadSources loads the namespace and pod labels (and pod IP) of all the pods of the cluster from the store.
+func (r *endpointReachability) loadSources(ctx context.Context, sp storedb.Provider) error {
+	namespaces := adapter.MongoDB(sp).Collection(collections.NamespaceName)
+
+	cur, err := namespaces.Find(context.Background(), bson.M{}, options.Find().SetProjection(bson.M{"name": 1, "labels": 1}))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	namespaceLabels := make(map[string]map[string]string)
+	for cur.Next(ctx) {
+		var ns store.Namespace
+		err := cur.Decode(&ns)
+		if err != nil {
+			return err
+		}
+
+		namespaceLabels[ns.Name] = ns.Labels
+	}
+
+	if err := cur.Err(); err != nil {
+		return err
+	}
+
+	pods := adapter.MongoDB(sp).Collection(collections.PodName)
+	projection := bson.M{
+		"namespace": "$k8.objectmeta.namespace",
+		"labels":    "$k8.objectmeta.labels",
+		"pod_ip":    "$k8.status.podip",
+	}
+
+	podCur, err := pods.Find(context.Background(), bson.M{}, options.Find().SetProjection(projection))
+	if err != nil {
+		return err
+	}
+	defer podCur.Close(ctx)
+
+	for podCur.Next(ctx) {
+		var pod struct {
+			Namespace string            `bson:"namespace"`
+			Labels    map[string]string `bson:"labels"`
+			PodIP     string            `bson:"pod_ip"`
+		}
+		err := podCur.Decode(&pod)
+		if err != nil {
+			return err
+		}
+
+		r.sources = append(r.sources, libkube.PolicySource{
+			Namespace:       pod.Namespace,
+			NamespaceLabels: namespaceLabels[pod.Namespace],
+			PodLabels:       pod.Labels,
+			PodIP:           pod.PodIP,
+		})
+	}
+
+	return podCur.Err()
+}
+
+// reachable returns whether the endpoint is reachable from any pod of the cluster. Endpoints of the pods of a same
+// workload share the same evaluation, so results are cached to avoid evaluating the policies against all pods again.
+func (r *endpointReachability) reachable(ingress *endpointIngress) bool {
+	policies := r.policies[ingress.PodNamespace]
+	if len(policies) == 0 {
+		return true
+	}
+
+	port := ingress.policyPort()
+	key := fmt.Sprintf("%s|%s|%d|%s|%s", ingress.PodNamespace, labels.Set(ingress.PodLabels).String(),
+		port.Port, port.Name, port.Protocol)
+	if reachable, ok := r.results[key]; ok {
+		return reachable
+	}
+
+	reachable := libkube.IngressReachable(policies, ingress.PodNamespace, ingress.PodLabels, port, r.sources)
+	r.results[key] = reachable
+
+	return reachable
+}
+
+// Callback wraps an edge callback to set the reachability of the endpoint query results before processing.
+func (r *endpointReachability) Callback(callback types.ProcessEntryCallback) types.ProcessEntryCallback {
+	return func(ctx context.Context, entry types.DataContainer) error {
+		typed, ok := entry.(endpointIngressEntry)
+		if !ok {
+			return fmt.Errorf("invalid type passed to endpoint reachability callback: %T", entry)
+		}
+
+		ingress := typed.ingress()
+		ingress.Reachable = r.reachable(ingress)
+
+		return callback(ctx, entry)
+	}
+}
+
+// endpointExploitProcessor creates an ENDPOINT_EXPLOIT edge between an endpoint and the container exposing it,
+// annotated with the reachability of the endpoint.
+func endpointExploitProcessor(ctx context.Context, oic *converter.ObjectIDConverter, label string,
+	endpoint primitive.ObjectID, container primitive.ObjectID, ingress *endpointIngress) (any, error) {
+
+	processed, err := adapter.GremlinEdgeProcessor(ctx, oic, label, endpoint, container)
+	if err != nil {
+		return nil, err
+	}
+
+	processed["reachable"] = ingress.Reachable
+
+	return processed, nil
+}
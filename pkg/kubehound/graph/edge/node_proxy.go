@@ -0,0 +1,130 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Requests to access the kubelet API via the nodes/proxy subresource. The kubelet maps HTTP methods to verbs, GET
+// requests (e.g /pods) require get while POST requests (e.g /run or /exec) require create.
+var nodeProxyRequests = []libkube.ResourceRequest{
+	{Verb: "get", APIGroup: "", Resource: "nodes", Subresource: "proxy"},
+	{Verb: "create", APIGroup: "", Resource: "nodes", Subresource: "proxy"},
+}
+
+func init() {
+	Register(&NodeProxy{}, RegisterGraphMutation)
+}
+
+type NodeProxy struct {
+	BaseEdge
+}
+
+type nodeProxyGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+func (e *NodeProxy) Label() string {
+	return "NODE_PROXY"
+}
+
+func (e *NodeProxy) Name() string {
+	return "NodeProxy"
+}
+
+func (e *NodeProxy) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *NodeProxy) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*nodeProxyGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *NodeProxy) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rnp").
+				MergeV(__.Select("rnp")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on NODE_PROXY insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				HasLabel("Node").
+				Has("class", "Node").
+				As("n").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("n").
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and have nodes/proxy or equivalent wildcard permissions on all nodes.
+// Permissions restricted to specific node names are handled by the NodeProxyNamed edge builder. Nodes are not namespaced
+// so namespaced roles cannot grant access to the kubelet API.
+func (e *NodeProxy) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	filter := bson.M{
+		"is_namespaced": false,
+	}
+
+	err := streamPermissionSets(ctx, store, filter, nodeProxyRequests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !rulesAllowAny(ps.Rules, nodeProxyRequests...) {
+			return nil
+		}
+
+		return callback(ctx, &nodeProxyGroup{Role: ps.Id})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
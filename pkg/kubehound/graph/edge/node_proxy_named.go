@@ -0,0 +1,80 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&NodeProxyNamed{}, RegisterDefault)
+}
+
+type NodeProxyNamed struct {
+	BaseEdge
+}
+
+type nodeProxyNamedGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+	Node primitive.ObjectID `bson:"node" json:"node"`
+}
+
+func (e *NodeProxyNamed) Label() string {
+	return "NODE_PROXY"
+}
+
+func (e *NodeProxyNamed) Name() string {
+	return "NodeProxyNamed"
+}
+
+func (e *NodeProxyNamed) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*nodeProxyNamedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Node)
+}
+
+// Stream finds all roles that are NOT namespaced and have nodes/proxy or equivalent wildcard permissions restricted to
+// resource names, and the matching nodes. Unrestricted permissions are handled by the NodeProxy edge builder.
+func (e *NodeProxyNamed) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	filter := bson.M{
+		"is_namespaced": false,
+	}
+
+	// Nodes are not namespaced and only cluster wide permission sets are streamed
+	batch := newTargetBatch(collections.NodeName, bson.M{}, "k8.objectmeta.namespace", "k8.objectmeta.name", false)
+	err := streamPermissionSets(ctx, store, filter, nodeProxyRequests, func(ctx context.Context, ps *permissionSetRules) error {
+		scope := rulesResourceScope(ps.Rules, nodeProxyRequests...)
+		if !scope.Allowed() || scope.All {
+			return nil
+		}
+
+		batch.Add(ps, scope)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, node primitive.ObjectID) error {
+		return callback(ctx, &nodeProxyNamedGroup{Role: role, Node: node})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -4,18 +4,24 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Requests to create pods, either directly or via a replication controller. Workload controllers outside of the core
+// API group are handled by WORKLOAD_CREATE.
+var podCreateRequests = []libkube.ResourceRequest{
+	{Verb: "create", APIGroup: "", Resource: "pods"},
+	{Verb: "create", APIGroup: "", Resource: "replicationcontrollers"},
+}
+
 func init() {
 	Register(&PodCreate{}, RegisterGraphMutation)
 }
@@ -99,54 +105,27 @@ func (e *PodCreate) Traversal() types.EdgeTraversal {
 	}
 }
 
-// Stream finds all roles that have pod/create or equivalent wildcard permissions.
+// Stream finds all roles that have pod/create or equivalent wildcard permissions. Create requests carry no resource
+// name, so rules restricted to resource names never grant this permission. Roles restricted to a namespace enforcing
+// the baseline or restricted pod security standards cannot create a privileged pod and are excluded.
 func (e *PodCreate) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods"},
-								bson.M{"resources": "cronjobs"},
-								bson.M{"resources": "deamonsets"},
-								bson.M{"resources": "deployments"},
-								bson.M{"resources": "jobs"},
-								bson.M{"resources": "replicasets"},
-								bson.M{"resources": "replicationcontrollers"},
-								bson.M{"resources": "statefulsets"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "create"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
-		},
+	enforced, err := loadPodSecurityNamespaces(ctx, store)
+	if err != nil {
+		return err
 	}
 
-	cur, err := permissionSets.Aggregate(context.Background(), pipeline)
+	err = streamPermissionSets(ctx, store, bson.M{}, podCreateRequests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !rulesAllowAny(ps.Rules, podCreateRequests...) || enforced.PreventsPrivileged(ps) {
+			return nil
+		}
+
+		return callback(ctx, &podCreateGroup{Role: ps.Id})
+	})
 	if err != nil {
 		return err
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[podCreateGroup](ctx, cur, callback, complete)
+	return complete(ctx)
 }
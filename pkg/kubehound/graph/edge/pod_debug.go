@@ -0,0 +1,129 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Requests to add an ephemeral container to a running pod (kubectl debug)
+var podDebugRequests = []libkube.ResourceRequest{
+	{Verb: "patch", APIGroup: "", Resource: "pods", Subresource: "ephemeralcontainers"},
+	{Verb: "update", APIGroup: "", Resource: "pods", Subresource: "ephemeralcontainers"},
+}
+
+func init() {
+	Register(&PodDebug{}, RegisterGraphMutation)
+}
+
+type PodDebug struct {
+	BaseEdge
+}
+
+type podDebugGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+func (e *PodDebug) Label() string {
+	return "POD_DEBUG"
+}
+
+func (e *PodDebug) Name() string {
+	return "PodDebug"
+}
+
+func (e *PodDebug) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *PodDebug) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*podDebugGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *PodDebug) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rpd").
+				MergeV(__.Select("rpd")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on POD_DEBUG insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				HasLabel("Pod").
+				Has("class", "Pod").
+				As("p").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("p").
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and have pods/ephemeralcontainers patch/update or equivalent wildcard
+// permissions on all pods.
+// Permissions restricted to specific pod names are handled by the PodDebugNamespace edge builder.
+func (e *PodDebug) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	filter := bson.M{
+		"is_namespaced": false,
+	}
+
+	err := streamPermissionSets(ctx, store, filter, podDebugRequests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !rulesAllowAny(ps.Rules, podDebugRequests...) {
+			return nil
+		}
+
+		return callback(ctx, &podDebugGroup{Role: ps.Id})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -0,0 +1,77 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&PodDebugNamespace{}, RegisterDefault)
+}
+
+type PodDebugNamespace struct {
+	BaseEdge
+}
+
+type podDebugNSGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+	Pod  primitive.ObjectID `bson:"pod" json:"pod"`
+}
+
+func (e *PodDebugNamespace) Label() string {
+	return "POD_DEBUG"
+}
+
+func (e *PodDebugNamespace) Name() string {
+	return "PodDebugNamespace"
+}
+
+func (e *PodDebugNamespace) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*podDebugNSGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod)
+}
+
+// Stream finds all roles that have pods/ephemeralcontainers patch/update or equivalent wildcard permissions and matching
+// pods. Matching pods are defined as all pods that share the role namespace or non-namespaced pods, restricted to the
+// rule resource names if present. Roles that are NOT namespaced are only considered here if restricted to resource
+// names (see PodDebug).
+func (e *PodDebugNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	batch := newTargetBatch(collections.PodName, bson.M{}, "k8.objectmeta.namespace", "k8.objectmeta.name", true)
+	err := streamPermissionSets(ctx, store, bson.M{}, podDebugRequests, func(ctx context.Context, ps *permissionSetRules) error {
+		scope := rulesResourceScope(ps.Rules, podDebugRequests...)
+		if !scope.Allowed() || (!ps.IsNamespaced && scope.All) {
+			return nil
+		}
+
+		batch.Add(ps, scope)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, pod primitive.ObjectID) error {
+		return callback(ctx, &podDebugNSGroup{Role: role, Pod: pod})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
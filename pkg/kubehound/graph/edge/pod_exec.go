@@ -4,18 +4,20 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Request to execute commands within a pod (kubectl exec)
+var podExecRequest = libkube.ResourceRequest{Verb: "create", APIGroup: "", Resource: "pods", Subresource: "exec"}
+
 func init() {
 	Register(&PodExec{}, RegisterGraphMutation)
 }
@@ -99,49 +101,26 @@ func (e *PodExec) Traversal() types.EdgeTraversal {
 	}
 }
 
-// Stream finds all roles that are NOT namespaced and have pod/exec or equivalent wildcard permissions.
+// Stream finds all roles that are NOT namespaced and have pod/exec or equivalent wildcard permissions on all pods.
+// Permissions restricted to specific pod names are handled by the PodExecNamespace edge builder.
 func (e *PodExec) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced": false,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods/exec"},
-								bson.M{"resources": "pods/*"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "create"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
-		},
+	filter := bson.M{
+		"is_namespaced": false,
 	}
 
-	cur, err := permissionSets.Aggregate(context.Background(), pipeline)
+	requests := []libkube.ResourceRequest{podExecRequest}
+	err := streamPermissionSets(ctx, store, filter, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !libkube.RulesAllow(ps.Rules, podExecRequest) {
+			return nil
+		}
+
+		return callback(ctx, &podExecGroup{Role: ps.Id})
+	})
 	if err != nil {
 		return err
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[podExecGroup](ctx, cur, callback, complete)
+	return complete(ctx)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
@@ -44,80 +45,34 @@ func (e *PodExecNamespace) Processor(ctx context.Context, oic *converter.ObjectI
 	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod)
 }
 
-// Stream finds all roles that are namespaced and have pod/exec or equivalent wildcard permissions and matching pods.
-// Matching pods are defined as all pods that share the role namespace or non-namespaced pods.
+// Stream finds all roles that have pod/exec or equivalent wildcard permissions and matching pods. Matching pods are
+// defined as all pods that share the role namespace or non-namespaced pods, restricted to the rule resource names if
+// present. Roles that are NOT namespaced are only considered here if restricted to resource names (see PodExec).
 func (e *PodExecNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced": true,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods/exec"},
-								bson.M{"resources": "pods/*"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "create"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"as":   "podsInNamespace",
-				"from": "pods",
-				"let": bson.M{
-					"roleNamespace": "$namespace",
-				},
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{"$or": bson.A{
-							bson.M{"$expr": bson.M{
-								"$eq": bson.A{
-									"$k8.objectmeta.namespace", "$$roleNamespace",
-								},
-							}},
-							bson.M{"is_namespaced": false},
-						}},
-					},
-					{
-						"$project": bson.M{
-							"_id": 1,
-						},
-					},
-				},
-			},
-		},
-		{
-			"$unwind": "$podsInNamespace",
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-				"pod": "$podsInNamespace._id",
-			},
-		},
+	requests := []libkube.ResourceRequest{podExecRequest}
+	batch := newTargetBatch(collections.PodName, bson.M{}, "k8.objectmeta.namespace", "k8.objectmeta.name", true)
+	err := streamPermissionSets(ctx, store, bson.M{}, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		scope := libkube.RulesResourceScope(ps.Rules, podExecRequest)
+		if !scope.Allowed() || (!ps.IsNamespaced && scope.All) {
+			return nil
+		}
+
+		batch.Add(ps, scope)
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	cur, err := permissionSets.Aggregate(context.Background(), pipeline)
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, pod primitive.ObjectID) error {
+		return callback(ctx, &podExecNSGroup{Role: role, Pod: pod})
+	})
 	if err != nil {
 		return err
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[podExecNSGroup](ctx, cur, callback, complete)
+	return complete(ctx)
 }
@@ -4,18 +4,26 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Requests to modify pods, either directly or via their controller
+var podPatchRequests = append(podControllerRequests("patch"), podControllerRequests("update")...)
+
+// Requests to modify pods directly
+var podPatchPodRequests = []libkube.ResourceRequest{
+	{Verb: "patch", APIGroup: "", Resource: "pods"},
+	{Verb: "update", APIGroup: "", Resource: "pods"},
+}
+
 func init() {
 	Register(&PodPatch{}, RegisterGraphMutation)
 }
@@ -99,56 +107,25 @@ func (e *PodPatch) Traversal() types.EdgeTraversal {
 	}
 }
 
-// Stream finds all roles that have pod/patch or equivalent wildcard permissions.
+// Stream finds all roles that are NOT namespaced and have pod/patch or equivalent wildcard permissions on all pods
+// (or pod controllers). Permissions restricted to specific pod names are handled by the PodPatchNamespace edge builder.
 func (e *PodPatch) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced": false,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods"},
-								bson.M{"resources": "cronjobs"},
-								bson.M{"resources": "deamonsets"},
-								bson.M{"resources": "deployments"},
-								bson.M{"resources": "jobs"},
-								bson.M{"resources": "replicasets"},
-								bson.M{"resources": "replicationcontrollers"},
-								bson.M{"resources": "statefulsets"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "patch"},
-								bson.M{"verbs": "update"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
-		},
+	filter := bson.M{
+		"is_namespaced": false,
 	}
 
-	cur, err := permissionSets.Aggregate(context.Background(), pipeline)
+	err := streamPermissionSets(ctx, store, filter, podPatchRequests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !rulesAllowAny(ps.Rules, podPatchRequests...) {
+			return nil
+		}
+
+		return callback(ctx, &podPatchGroup{Role: ps.Id})
+	})
 	if err != nil {
 		return err
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[podPatchGroup](ctx, cur, callback, complete)
+	return complete(ctx)
 }
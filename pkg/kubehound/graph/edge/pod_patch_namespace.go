@@ -6,6 +6,7 @@ import (
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
@@ -44,87 +45,44 @@ func (e *PodPatchNamespace) Processor(ctx context.Context, oic *converter.Object
 	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod)
 }
 
-// Stream finds all roles that are namespaced and have pod/exec or equivalent wildcard permissions and matching pods.
-// Matching pods are defined as all pods that share the role namespace or non-namespaced pods.
+// Stream finds all roles that have pod/patch or equivalent wildcard permissions and matching pods. Matching pods are
+// defined as all pods that share the role namespace or non-namespaced pods. Permissions on pod controllers grant
+// access to all their pods, while permissions on pods are restricted to the rule resource names if present. Roles that
+// are NOT namespaced are only considered here if restricted to resource names (see PodPatch).
 func (e *PodPatchNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced": true,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods"},
-								bson.M{"resources": "cronjobs"},
-								bson.M{"resources": "deamonsets"},
-								bson.M{"resources": "deployments"},
-								bson.M{"resources": "jobs"},
-								bson.M{"resources": "replicasets"},
-								bson.M{"resources": "replicationcontrollers"},
-								bson.M{"resources": "statefulsets"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "patch"},
-								bson.M{"verbs": "update"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"as":   "podsInNamespace",
-				"from": "pods",
-				"let": bson.M{
-					"roleNamespace": "$namespace",
-				},
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{"$or": bson.A{
-							bson.M{"$expr": bson.M{
-								"$eq": bson.A{
-									"$k8.objectmeta.namespace", "$$roleNamespace",
-								},
-							}},
-							bson.M{"is_namespaced": false},
-						}},
-					},
-					{
-						"$project": bson.M{
-							"_id": 1,
-						},
-					},
-				},
-			},
-		},
-		{
-			"$unwind": "$podsInNamespace",
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-				"pod": "$podsInNamespace._id",
-			},
-		},
+	requests := append(append([]libkube.ResourceRequest{}, podPatchRequests...), podPatchPodRequests...)
+	batch := newTargetBatch(collections.PodName, bson.M{}, "k8.objectmeta.namespace", "k8.objectmeta.name", true)
+	err := streamPermissionSets(ctx, store, bson.M{}, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		var scope libkube.ResourceScope
+		if rulesAllowAny(ps.Rules, podPatchRequests...) {
+			if !ps.IsNamespaced {
+				return nil
+			}
+			scope = libkube.ResourceScope{All: true}
+		} else {
+			// The names of the pods created by a controller cannot be derived from the controller name
+			scope = rulesResourceScope(ps.Rules, podPatchPodRequests...)
+			if !scope.Allowed() {
+				return nil
+			}
+		}
+
+		batch.Add(ps, scope)
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	cur, err := permissionSets.Aggregate(context.Background(), pipeline)
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, pod primitive.ObjectID) error {
+		return callback(ctx, &podPatchNSGroup{Role: role, Pod: pod})
+	})
 	if err != nil {
 		return err
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[podPatchNSGroup](ctx, cur, callback, complete)
+	return complete(ctx)
 }
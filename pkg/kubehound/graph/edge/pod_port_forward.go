@@ -0,0 +1,130 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Request to forward a local port to a pod port (kubectl port-forward)
+var podPortForwardRequest = libkube.ResourceRequest{Verb: "create", APIGroup: "", Resource: "pods",
+	Subresource: "portforward"}
+
+func init() {
+	Register(&PodPortForward{}, RegisterGraphMutation)
+}
+
+type PodPortForward struct {
+	BaseEdge
+}
+
+type podPortForwardGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+func (e *PodPortForward) Label() string {
+	return "POD_PORT_FORWARD"
+}
+
+func (e *PodPortForward) Name() string {
+	return "PodPortForward"
+}
+
+func (e *PodPortForward) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *PodPortForward) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*podPortForwardGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *PodPortForward) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rpf").
+				MergeV(__.Select("rpf")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on POD_PORT_FORWARD insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				HasLabel("Endpoint").
+				Has("class", "Endpoint").
+				Has("exposure", gremlin.P.Within(int(shared.EndpointExposureNone), int(shared.EndpointExposureClusterIP))).
+				As("e").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("e").
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and have pods/portforward or equivalent wildcard permissions on all
+// pods, granting access to all the private container endpoints of the cluster. Permissions restricted to specific pod
+// names are handled by the PodPortForwardNamespace edge builder.
+func (e *PodPortForward) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	filter := bson.M{
+		"is_namespaced": false,
+	}
+
+	requests := []libkube.ResourceRequest{podPortForwardRequest}
+	err := streamPermissionSets(ctx, store, filter, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !libkube.RulesAllow(ps.Rules, podPortForwardRequest) {
+			return nil
+		}
+
+		return callback(ctx, &podPortForwardGroup{Role: ps.Id})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -0,0 +1,87 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Private endpoints are created from the container ports with no associated endpoint slice, and are reachable via a
+// port forward regardless of the network exposure
+var privateEndpointFilter = bson.M{
+	"has_slice": false,
+	"access":    bson.M{"$in": bson.A{shared.EndpointExposureNone, shared.EndpointExposureClusterIP}},
+}
+
+func init() {
+	Register(&PodPortForwardNamespace{}, RegisterDefault)
+}
+
+type PodPortForwardNamespace struct {
+	BaseEdge
+}
+
+type podPortForwardNSGroup struct {
+	Role     primitive.ObjectID `bson:"_id" json:"role"`
+	Endpoint primitive.ObjectID `bson:"endpoint" json:"endpoint"`
+}
+
+func (e *PodPortForwardNamespace) Label() string {
+	return "POD_PORT_FORWARD"
+}
+
+func (e *PodPortForwardNamespace) Name() string {
+	return "PodPortForwardNamespace"
+}
+
+func (e *PodPortForwardNamespace) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*podPortForwardNSGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Endpoint)
+}
+
+// Stream finds all roles that have pods/portforward or equivalent wildcard permissions and the private container
+// endpoints of matching pods. Matching pods are defined as all pods that share the role namespace or non-namespaced
+// pods, restricted to the rule resource names if present. Roles that are NOT namespaced are only considered here if
+// restricted to resource names (see PodPortForward).
+func (e *PodPortForwardNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	requests := []libkube.ResourceRequest{podPortForwardRequest}
+	batch := newTargetBatch(collections.EndpointName, privateEndpointFilter, "pod_namespace", "pod_name", true)
+	err := streamPermissionSets(ctx, store, bson.M{}, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		scope := libkube.RulesResourceScope(ps.Rules, podPortForwardRequest)
+		if !scope.Allowed() || (!ps.IsNamespaced && scope.All) {
+			return nil
+		}
+
+		batch.Add(ps, scope)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, ep primitive.ObjectID) error {
+		return callback(ctx, &podPortForwardNSGroup{Role: role, Endpoint: ep})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -0,0 +1,65 @@
+package edge
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// podSecurityNamespaces holds the set of namespaces whose enforced pod security admission level prevents the creation
+// of privileged pods.
+type podSecurityNamespaces map[string]struct{}
+
+// loadPodSecurityNamespaces loads all the namespaces enforcing a pod security standards level that prevents privileged
+// pods from the store. The number of namespaces is expected to be small enough to be held in memory.
+func loadPodSecurityNamespaces(ctx context.Context, sp storedb.Provider) (podSecurityNamespaces, error) {
+	namespaces := adapter.MongoDB(sp).Collection(collections.NamespaceName)
+	projection := bson.M{"name": 1, "psa_enforce": 1}
+
+	cur, err := namespaces.Find(context.Background(), bson.M{}, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	enforced := make(podSecurityNamespaces)
+	for cur.Next(ctx) {
+		var entry struct {
+			Name       string `bson:"name"`
+			PSAEnforce string `bson:"psa_enforce"`
+		}
+		err := cur.Decode(&entry)
+		if err != nil {
+			return nil, err
+		}
+
+		if libkube.PodSecurityAllowsPrivileged(entry.PSAEnforce) {
+			continue
+		}
+
+		enforced[entry.Name] = struct{}{}
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return enforced, nil
+}
+
+// PreventsPrivileged returns whether the pods created from a permission set are prevented from being privileged by the
+// pod security admission. Cluster wide permission sets can always create pods in a namespace without enforcement.
+func (n podSecurityNamespaces) PreventsPrivileged(ps *permissionSetRules) bool {
+	if !ps.IsNamespaced {
+		return false
+	}
+
+	_, ok := n[ps.Namespace]
+
+	return ok
+}
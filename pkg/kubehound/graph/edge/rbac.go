@@ -0,0 +1,281 @@
+package edge
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// permissionSetRules is the subset of a permission set store entry required to evaluate its RBAC rules.
+type permissionSetRules struct {
+	Id           primitive.ObjectID  `bson:"_id"`
+	RoleName     string              `bson:"role_name"`
+	IsNamespaced bool                `bson:"is_namespaced"`
+	Namespace    string              `bson:"namespace"`
+	Rules        []rbacv1.PolicyRule `bson:"rules"`
+}
+
+// permissionSetHandler is invoked on every permission set streamed by streamPermissionSets.
+type permissionSetHandler func(ctx context.Context, ps *permissionSetRules) error
+
+// targetHandler is invoked on every target id streamed by streamTargets.
+type targetHandler func(ctx context.Context, target primitive.ObjectID) error
+
+// scopedTargetHandler is invoked on every (permission set, target) pair streamed by a targetBatch.
+type scopedTargetHandler func(ctx context.Context, role primitive.ObjectID, target primitive.ObjectID) error
+
+// podControllerRequests returns the requests on all the resources that create (and control) pods for the provided verb.
+func podControllerRequests(verb string) []libkube.ResourceRequest {
+	return []libkube.ResourceRequest{
+		{Verb: verb, APIGroup: "", Resource: "pods"},
+		{Verb: verb, APIGroup: "", Resource: "replicationcontrollers"},
+		{Verb: verb, APIGroup: "apps", Resource: "daemonsets"},
+		{Verb: verb, APIGroup: "apps", Resource: "deployments"},
+		{Verb: verb, APIGroup: "apps", Resource: "replicasets"},
+		{Verb: verb, APIGroup: "apps", Resource: "statefulsets"},
+		{Verb: verb, APIGroup: "batch", Resource: "cronjobs"},
+		{Verb: verb, APIGroup: "batch", Resource: "jobs"},
+	}
+}
+
+// rulesAllowAny returns whether the rules grant any of the provided requests.
+func rulesAllowAny(rules []rbacv1.PolicyRule, requests ...libkube.ResourceRequest) bool {
+	for _, request := range requests {
+		if libkube.RulesAllow(rules, request) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rulesResourceScope returns the union of the resource scopes granted by the rules on the provided requests.
+func rulesResourceScope(rules []rbacv1.PolicyRule, requests ...libkube.ResourceRequest) libkube.ResourceScope {
+	scopes := make([]libkube.ResourceScope, 0, len(requests))
+	for _, request := range requests {
+		scopes = append(scopes, libkube.RulesResourceScope(rules, request))
+	}
+
+	return libkube.MergeResourceScopes(scopes...)
+}
+
+// rulesFilter returns a mongo filter matching the permission sets with at least one rule that may grant any of the
+// provided requests. The filter matches the verbs, API groups and resources of the rules (including wildcards) and
+// ignores resource names, so that it selects a superset of the permission sets granting the requests.
+func rulesFilter(requests ...libkube.ResourceRequest) bson.M {
+	candidates := make(bson.A, 0, len(requests))
+	for _, request := range requests {
+		resources := bson.A{rbacv1.ResourceAll, request.CombinedResource()}
+		if len(request.Subresource) != 0 {
+			resources = append(resources, "*/"+request.Subresource)
+		}
+
+		candidates = append(candidates, bson.M{
+			"verbs":     bson.M{"$in": bson.A{rbacv1.VerbAll, request.Verb}},
+			"apigroups": bson.M{"$in": bson.A{rbacv1.APIGroupAll, request.APIGroup}},
+			"resources": bson.M{"$in": resources},
+		})
+	}
+
+	return bson.M{"rules": bson.M{"$elemMatch": bson.M{"$or": candidates}}}
+}
+
+// streamPermissionSets invokes the handler on all permission sets matching the provided filter and holding a rule that
+// may grant any of the provided requests. RBAC rules cannot be faithfully evaluated within a mongo query, so the query
+// only prefilters candidates (see rulesFilter) and the evaluation is delegated to the handler via the libkube helpers.
+func streamPermissionSets(ctx context.Context, store storedb.Provider, filter bson.M, requests []libkube.ResourceRequest,
+	handler permissionSetHandler) error {
+
+	permissionSets := adapter.MongoDB(store).Collection(collections.PermissionSetName)
+	projection := bson.M{"_id": 1, "role_name": 1, "is_namespaced": 1, "namespace": 1, "rules": 1}
+	query := bson.M{"$and": bson.A{filter, rulesFilter(requests...)}}
+
+	cur, err := permissionSets.Find(context.Background(), query, options.Find().SetProjection(projection))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var ps permissionSetRules
+		err := cur.Decode(&ps)
+		if err != nil {
+			return err
+		}
+
+		err = handler(ctx, &ps)
+		if err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+// streamTargets invokes the handler on the id of all entries of the collection matching the provided filter.
+func streamTargets(ctx context.Context, store storedb.Provider, collection string, filter bson.M, handler targetHandler) error {
+	targets := adapter.MongoDB(store).Collection(collection)
+
+	cur, err := targets.Find(context.Background(), filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var entry struct {
+			Id primitive.ObjectID `bson:"_id"`
+		}
+		err := cur.Decode(&entry)
+		if err != nil {
+			return err
+		}
+
+		err = handler(ctx, entry.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+// loadTargets returns the ids of all entries of the collection matching the provided filter.
+func loadTargets(ctx context.Context, store storedb.Provider, collection string, filter bson.M) ([]primitive.ObjectID, error) {
+	targets := make([]primitive.ObjectID, 0)
+	err := streamTargets(ctx, store, collection, filter, func(ctx context.Context, target primitive.ObjectID) error {
+		targets = append(targets, target)
+
+		return nil
+	})
+
+	return targets, err
+}
+
+// scopedPermissionSet is a permission set along with the scope of the targets its rules grant access to.
+type scopedPermissionSet struct {
+	Id    primitive.ObjectID
+	Scope libkube.ResourceScope
+}
+
+// targetBatch batches the target lookups of permission sets, issuing a single query per namespace (and one for all
+// cluster wide permission sets) rather than one query per permission set. The resource names of the scopes are then
+// matched against the targets in memory.
+type targetBatch struct {
+	collection     string                           // Collection holding the targets
+	filter         bson.M                           // Base filter applied to all targets
+	namespaceField string                           // Field holding the namespace of a target
+	nameField      string                           // Field holding the name of a target
+	clusterTargets bool                             // Whether namespaced permission sets also reach non-namespaced targets
+	clusterWide    []scopedPermissionSet            // Cluster wide permission sets
+	namespaced     map[string][]scopedPermissionSet // Namespaced permission sets, indexed by namespace
+}
+
+// newTargetBatch creates a new target batch on the provided collection. Namespaced permission sets are granted access
+// to the targets within their namespace and, if clusterTargets is set, to non-namespaced targets.
+func newTargetBatch(collection string, filter bson.M, namespaceField string, nameField string,
+	clusterTargets bool) *targetBatch {
+
+	return &targetBatch{
+		collection:     collection,
+		filter:         filter,
+		namespaceField: namespaceField,
+		nameField:      nameField,
+		clusterTargets: clusterTargets,
+		namespaced:     make(map[string][]scopedPermissionSet),
+	}
+}
+
+// Add registers a permission set granting access to the targets within the provided scope.
+func (b *targetBatch) Add(ps *permissionSetRules, scope libkube.ResourceScope) {
+	entry := scopedPermissionSet{Id: ps.Id, Scope: scope}
+	if !ps.IsNamespaced {
+		b.clusterWide = append(b.clusterWide, entry)
+
+		return
+	}
+
+	b.namespaced[ps.Namespace] = append(b.namespaced[ps.Namespace], entry)
+}
+
+// Stream invokes the handler on all (permission set, target) pairs of the batch.
+func (b *targetBatch) Stream(ctx context.Context, store storedb.Provider, handler scopedTargetHandler) error {
+	if len(b.clusterWide) != 0 {
+		err := b.streamGroup(ctx, store, b.filter, b.clusterWide, handler)
+		if err != nil {
+			return err
+		}
+	}
+
+	for namespace, group := range b.namespaced {
+		namespaceFilter := bson.M{b.namespaceField: namespace}
+		if b.clusterTargets {
+			namespaceFilter = bson.M{"$or": bson.A{namespaceFilter, bson.M{"is_namespaced": false}}}
+		}
+
+		err := b.streamGroup(ctx, store, bson.M{"$and": bson.A{b.filter, namespaceFilter}}, group, handler)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// streamGroup queries the targets matching the filter and the union of the group scopes, then invokes the handler on
+// each target for all the permission sets of the group whose scope contains the target.
+func (b *targetBatch) streamGroup(ctx context.Context, store storedb.Provider, filter bson.M,
+	group []scopedPermissionSet, handler scopedTargetHandler) error {
+
+	scopes := make([]libkube.ResourceScope, 0, len(group))
+	for _, ps := range group {
+		scopes = append(scopes, ps.Scope)
+	}
+
+	merged := libkube.MergeResourceScopes(scopes...)
+	if !merged.All {
+		filter = bson.M{"$and": bson.A{filter, bson.M{b.nameField: bson.M{"$in": merged.Names}}}}
+	}
+
+	targets := adapter.MongoDB(store).Collection(b.collection)
+	projection := bson.M{"_id": 1, b.nameField: 1}
+
+	cur, err := targets.Find(context.Background(), filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	namePath := strings.Split(b.nameField, ".")
+	for cur.Next(ctx) {
+		var entry struct {
+			Id primitive.ObjectID `bson:"_id"`
+		}
+		err := cur.Decode(&entry)
+		if err != nil {
+			return err
+		}
+
+		name, _ := cur.Current.Lookup(namePath...).StringValueOK()
+		for _, ps := range group {
+			if !ps.Scope.Contains(name) {
+				continue
+			}
+
+			err := handler(ctx, ps.Id, entry.Id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return cur.Err()
+}
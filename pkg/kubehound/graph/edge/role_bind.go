@@ -0,0 +1,152 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Requests to create role bindings. A namespaced permission set can only create rolebindings in its own namespace.
+var roleBindRequests = []libkube.ResourceRequest{
+	{Verb: "create", APIGroup: rbacv1.GroupName, Resource: "rolebindings"},
+	{Verb: "create", APIGroup: rbacv1.GroupName, Resource: "clusterrolebindings"},
+}
+
+func init() {
+	Register(&RoleBind{}, RegisterGraphMutation)
+}
+
+type RoleBind struct {
+	BaseEdge
+}
+
+type roleBindGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+func (e *RoleBind) Label() string {
+	return "ROLE_BIND"
+}
+
+func (e *RoleBind) Name() string {
+	return "RoleBind"
+}
+
+func (e *RoleBind) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *RoleBind) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*roleBindGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *RoleBind) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rrb").
+				MergeV(__.Select("rrb")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on ROLE_BIND insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				HasLabel("PermissionSet").
+				Has("class", "PermissionSet").
+				As("ps").
+				V(inserts...).
+				Has("critical", false).
+				Where(P.Neq("ps")).
+				AddE(e.Label()).
+				To("ps").
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and have (cluster)rolebindings/create permissions alongside the
+// ability to bind or escalate all (cluster)roles, including equivalent wildcard permissions. Bind permissions restricted
+// to specific role names are handled by the RoleBindNamespace edge builder.
+func (e *RoleBind) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	filter := bson.M{
+		"is_namespaced": false,
+	}
+
+	err := streamPermissionSets(ctx, store, filter, roleBindRequests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !roleBindScope(ps).All {
+			return nil
+		}
+
+		return callback(ctx, &roleBindGroup{Role: ps.Id})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
+
+// roleBindScope returns the scope of the (cluster)roles a permission set can bind. This requires the ability to create
+// (cluster)rolebindings AND to either bind or escalate (cluster)roles. The two permissions are typically granted via
+// separate rules within the same role. A namespaced permission set can only create rolebindings in its own namespace.
+func roleBindScope(ps *permissionSetRules) libkube.ResourceScope {
+	createRequests := roleBindRequests[:1]
+	if !ps.IsNamespaced {
+		createRequests = roleBindRequests
+	}
+
+	if !rulesAllowAny(ps.Rules, createRequests...) {
+		return libkube.ResourceScope{}
+	}
+
+	return rulesResourceScope(ps.Rules,
+		libkube.ResourceRequest{Verb: "bind", APIGroup: rbacv1.GroupName, Resource: "roles"},
+		libkube.ResourceRequest{Verb: "bind", APIGroup: rbacv1.GroupName, Resource: "clusterroles"},
+		libkube.ResourceRequest{Verb: "escalate", APIGroup: rbacv1.GroupName, Resource: "roles"},
+		libkube.ResourceRequest{Verb: "escalate", APIGroup: rbacv1.GroupName, Resource: "clusterroles"},
+	)
+}
@@ -0,0 +1,83 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&RoleBindNamespace{}, RegisterDefault)
+}
+
+type RoleBindNamespace struct {
+	BaseEdge
+}
+
+type roleBindNSGroup struct {
+	Role          primitive.ObjectID `bson:"_id" json:"role"`
+	PermissionSet primitive.ObjectID `bson:"permission_set" json:"permission_set"`
+}
+
+func (e *RoleBindNamespace) Label() string {
+	return "ROLE_BIND"
+}
+
+func (e *RoleBindNamespace) Name() string {
+	return "RoleBindNamespace"
+}
+
+func (e *RoleBindNamespace) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*roleBindNSGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.PermissionSet)
+}
+
+// Stream finds all roles that have rolebindings/create permissions alongside the ability to bind or escalate
+// (cluster)roles, and matching permission sets. For namespaced roles, matching permission sets are defined as all other
+// permission sets in the role namespace, as a RoleBinding can only grant permissions within its own namespace. In both
+// cases, matching permission sets are restricted to the rule resource names (i.e role names) if present. Roles that are
+// NOT namespaced are only considered here if restricted to resource names (see RoleBind).
+func (e *RoleBindNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	// A namespaced permission set can only bind roles within its own namespace, i.e namespaced permission sets
+	batch := newTargetBatch(collections.PermissionSetName, bson.M{}, "namespace", "role_name", false)
+	err := streamPermissionSets(ctx, store, bson.M{}, roleBindRequests, func(ctx context.Context, ps *permissionSetRules) error {
+		scope := roleBindScope(ps)
+		if !scope.Allowed() || (!ps.IsNamespaced && scope.All) {
+			return nil
+		}
+
+		batch.Add(ps, scope)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, target primitive.ObjectID) error {
+		if role == target {
+			return nil
+		}
+
+		return callback(ctx, &roleBindNSGroup{Role: role, PermissionSet: target})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -0,0 +1,129 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Request to mint a service account token via the TokenRequest API (kubectl create token)
+var saTokenCreateRequest = libkube.ResourceRequest{Verb: "create", APIGroup: "", Resource: "serviceaccounts",
+	Subresource: "token"}
+
+func init() {
+	Register(&ServiceAccountTokenCreate{}, RegisterGraphMutation)
+}
+
+type ServiceAccountTokenCreate struct {
+	BaseEdge
+}
+
+type saTokenCreateGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+func (e *ServiceAccountTokenCreate) Label() string {
+	return "SERVICE_ACCOUNT_TOKEN_CREATE"
+}
+
+func (e *ServiceAccountTokenCreate) Name() string {
+	return "ServiceAccountTokenCreate"
+}
+
+func (e *ServiceAccountTokenCreate) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *ServiceAccountTokenCreate) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*saTokenCreateGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *ServiceAccountTokenCreate) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rst").
+				MergeV(__.Select("rst")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on SERVICE_ACCOUNT_TOKEN_CREATE insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				HasLabel("Identity").
+				Has("class", "Identity").
+				Has("type", "ServiceAccount").
+				As("i").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("i").
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and have serviceaccounts/token create or equivalent wildcard permissions
+// on all service accounts. Permissions restricted to specific service account names are handled by the
+// ServiceAccountTokenCreateNamespace edge builder.
+func (e *ServiceAccountTokenCreate) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	filter := bson.M{
+		"is_namespaced": false,
+	}
+
+	requests := []libkube.ResourceRequest{saTokenCreateRequest}
+	err := streamPermissionSets(ctx, store, filter, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !libkube.RulesAllow(ps.Rules, saTokenCreateRequest) {
+			return nil
+		}
+
+		return callback(ctx, &saTokenCreateGroup{Role: ps.Id})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -0,0 +1,79 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&ServiceAccountTokenCreateNamespace{}, RegisterDefault)
+}
+
+type ServiceAccountTokenCreateNamespace struct {
+	BaseEdge
+}
+
+type saTokenCreateNSGroup struct {
+	Role     primitive.ObjectID `bson:"_id" json:"role"`
+	Identity primitive.ObjectID `bson:"identity" json:"identity"`
+}
+
+func (e *ServiceAccountTokenCreateNamespace) Label() string {
+	return "SERVICE_ACCOUNT_TOKEN_CREATE"
+}
+
+func (e *ServiceAccountTokenCreateNamespace) Name() string {
+	return "ServiceAccountTokenCreateNamespace"
+}
+
+func (e *ServiceAccountTokenCreateNamespace) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*saTokenCreateNSGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Identity)
+}
+
+// Stream finds all roles that have serviceaccounts/token create or equivalent wildcard permissions and matching service
+// accounts. Matching service accounts are defined as all service account identities that share the role namespace,
+// restricted to the rule resource names if present. Roles that are NOT namespaced are only considered here if
+// restricted to resource names (see ServiceAccountTokenCreate).
+func (e *ServiceAccountTokenCreateNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	requests := []libkube.ResourceRequest{saTokenCreateRequest}
+	batch := newTargetBatch(collections.IdentityName, bson.M{"type": "ServiceAccount"}, "namespace", "name", true)
+	err := streamPermissionSets(ctx, store, bson.M{}, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		scope := libkube.RulesResourceScope(ps.Rules, saTokenCreateRequest)
+		if !scope.Allowed() || (!ps.IsNamespaced && scope.All) {
+			return nil
+		}
+
+		batch.Add(ps, scope)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, identity primitive.ObjectID) error {
+		return callback(ctx, &saTokenCreateNSGroup{Role: role, Identity: identity})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -4,17 +4,19 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Request to get a secret (including service account token secrets) by name
+var tokenBruteforceRequest = libkube.ResourceRequest{Verb: "get", APIGroup: "", Resource: "secrets"}
+
 func init() {
 	Register(&TokenBruteforce{}, RegisterGraphMutation)
 }
@@ -93,44 +95,22 @@ func (e *TokenBruteforce) Traversal() types.EdgeTraversal {
 func (e *TokenBruteforce) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced": false,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "secrets"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "get"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
-		},
+	filter := bson.M{
+		"is_namespaced": false,
 	}
 
-	cur, err := permissionSets.Aggregate(context.Background(), pipeline)
+	requests := []libkube.ResourceRequest{tokenBruteforceRequest}
+	err := streamPermissionSets(ctx, store, filter, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		// Identities carry no secret name, so the rules MUST grant access to all secrets (see TokenBruteforceSecret)
+		if !libkube.RulesAllow(ps.Rules, tokenBruteforceRequest) {
+			return nil
+		}
+
+		return callback(ctx, &tokenBruteforceGroup{Role: ps.Id})
+	})
 	if err != nil {
 		return err
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[tokenBruteforceGroup](ctx, cur, callback, complete)
+	return complete(ctx)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
@@ -49,85 +50,37 @@ func (e *TokenBruteforceNamespace) Processor(ctx context.Context, oic *converter
 func (e *TokenBruteforceNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	var verbMatcher bson.M
-	if e.cfg.LargeClusterOptimizations {
-		// For large clusters do not create a redundant edge already covered by the TOKEN_LIST attack as this technique is much more complex
-		verbMatcher = bson.M{"verbs": "get"}
-	} else {
-		verbMatcher = bson.M{"$or": bson.A{
-			bson.M{"verbs": "get"},
-			bson.M{"verbs": "*"},
-		}}
+	filter := bson.M{
+		"is_namespaced": true,
 	}
 
-	permissionSets := adapter.MongoDB(store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced": true,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "secrets"},
-								bson.M{"resources": "*"},
-							}},
-							verbMatcher,
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"as":   "idsInNamespace",
-				"from": "identities",
-				"let": bson.M{
-					"roleNamespace": "$namespace",
-				},
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"$expr": bson.M{
-									"$eq": bson.A{
-										"$namespace", "$$roleNamespace",
-									},
-								}},
-								bson.M{"is_namespaced": false},
-							}},
-							bson.M{"type": "ServiceAccount"},
-						}},
-					},
-					{
-						"$project": bson.M{
-							"_id": 1,
-						},
-					},
-				},
-			},
-		},
-		{
-			"$unwind": "$idsInNamespace",
-		},
-		{
-			"$project": bson.M{
-				"_id":      1,
-				"identity": "$idsInNamespace._id",
-			},
-		},
+	requests := []libkube.ResourceRequest{tokenBruteforceRequest}
+	batch := newTargetBatch(collections.IdentityName, bson.M{"type": "ServiceAccount"}, "namespace", "name", true)
+	err := streamPermissionSets(ctx, store, filter, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		// Identities carry no secret name, so the rules MUST grant access to all secrets (see TokenBruteforceSecret)
+		if !libkube.RulesAllow(ps.Rules, tokenBruteforceRequest) {
+			return nil
+		}
+
+		if e.cfg.LargeClusterOptimizations && libkube.RulesAllow(ps.Rules, tokenListRequest) {
+			// For large clusters do not create a redundant edge already covered by the TOKEN_LIST attack as this technique is much more complex
+			return nil
+		}
+
+		batch.Add(ps, libkube.ResourceScope{All: true})
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	cur, err := permissionSets.Aggregate(context.Background(), pipeline)
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, identity primitive.ObjectID) error {
+		return callback(ctx, &tokenBruteforceNSGroup{Role: role, Identity: identity})
+	})
 	if err != nil {
 		return err
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[tokenBruteforceNSGroup](ctx, cur, callback, complete)
+	return complete(ctx)
 }
@@ -0,0 +1,92 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(&TokenBruteforceSecret{}, RegisterDefault)
+}
+
+type TokenBruteforceSecret struct {
+	BaseEdge
+}
+
+type tokenBruteforceSecretGroup struct {
+	Role   primitive.ObjectID `bson:"_id" json:"role"`
+	Secret primitive.ObjectID `bson:"secret" json:"secret"`
+}
+
+func (e *TokenBruteforceSecret) Label() string {
+	return "TOKEN_BRUTEFORCE"
+}
+
+func (e *TokenBruteforceSecret) Name() string {
+	return "TokenBruteforceSecret"
+}
+
+func (e *TokenBruteforceSecret) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*tokenBruteforceSecretGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Secret)
+}
+
+// Stream finds all roles that have secrets/get or equivalent wildcard permissions and the service account token
+// secrets they can get. As secret names are ingested, rules restricted to resource names are matched against the
+// concrete secrets. Secrets are only ingested if the collection of secrets metadata is enabled.
+func (e *TokenBruteforceSecret) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	requests := []libkube.ResourceRequest{tokenBruteforceRequest}
+	batch := newTargetBatch(collections.SecretName, bson.M{"type": string(corev1.SecretTypeServiceAccountToken)},
+		"namespace", "name", true)
+	err := streamPermissionSets(ctx, store, bson.M{}, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		scope := libkube.RulesResourceScope(ps.Rules, tokenBruteforceRequest)
+		if !scope.Allowed() {
+			return nil
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			if !ps.IsNamespaced {
+				// For large clusters cluster wide roles are already covered by the TOKEN_BRUTEFORCE attack to system:masters
+				return nil
+			}
+
+			if libkube.RulesAllow(ps.Rules, tokenListRequest) {
+				// For large clusters do not create a redundant edge already covered by the TOKEN_LIST attack
+				return nil
+			}
+		}
+
+		batch.Add(ps, scope)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, secret primitive.ObjectID) error {
+		return callback(ctx, &tokenBruteforceSecretGroup{Role: role, Secret: secret})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -0,0 +1,84 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(&TokenListSecret{}, RegisterDefault)
+}
+
+type TokenListSecret struct {
+	BaseEdge
+}
+
+type tokenListSecretGroup struct {
+	Role   primitive.ObjectID `bson:"_id" json:"role"`
+	Secret primitive.ObjectID `bson:"secret" json:"secret"`
+}
+
+func (e *TokenListSecret) Label() string {
+	return "TOKEN_LIST"
+}
+
+func (e *TokenListSecret) Name() string {
+	return "TokenListSecret"
+}
+
+func (e *TokenListSecret) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*tokenListSecretGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Secret)
+}
+
+// Stream finds all roles that have secrets/list or equivalent wildcard permissions and the service account token
+// secrets they can list. Secrets are only ingested if the collection of secrets metadata is enabled.
+func (e *TokenListSecret) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	requests := []libkube.ResourceRequest{tokenListRequest}
+	batch := newTargetBatch(collections.SecretName, bson.M{"type": string(corev1.SecretTypeServiceAccountToken)},
+		"namespace", "name", true)
+	err := streamPermissionSets(ctx, store, bson.M{}, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !libkube.RulesAllow(ps.Rules, tokenListRequest) {
+			return nil
+		}
+
+		if e.cfg.LargeClusterOptimizations && !ps.IsNamespaced {
+			// For large clusters cluster wide roles are already covered by the TOKEN_LIST attack to system:masters
+			return nil
+		}
+
+		// List requests carry no resource name, so the rules grant access to all secrets of the namespace
+		batch.Add(ps, libkube.ResourceScope{All: true})
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = batch.Stream(ctx, store, func(ctx context.Context, role primitive.ObjectID, secret primitive.ObjectID) error {
+		return callback(ctx, &tokenListSecretGroup{Role: role, Secret: secret})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
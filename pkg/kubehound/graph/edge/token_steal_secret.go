@@ -0,0 +1,166 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func init() {
+	Register(&TokenStealSecret{}, RegisterDefault)
+}
+
+type tokenStealSecretGroup struct {
+	Container primitive.ObjectID `bson:"container_id" json:"container"`
+	Identity  primitive.ObjectID `bson:"identity_id" json:"identity"`
+}
+
+type TokenStealSecret struct {
+	BaseEdge
+}
+
+func (e *TokenStealSecret) Label() string {
+	return "TOKEN_STEAL"
+}
+
+func (e *TokenStealSecret) Name() string {
+	return "TokenStealSecret"
+}
+
+func (e *TokenStealSecret) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*tokenStealSecretGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Container, typed.Identity)
+}
+
+// Stream finds all the containers exposed to a service account token secret, via environment variables or a secret
+// volume, and matches them to the identity of the service account the token authenticates as. This relies on the
+// secrets metadata, so no edge is created unless the collection of secrets is enabled.
+func (e *TokenStealSecret) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	containers := adapter.MongoDB(store).Collection(collections.ContainerName)
+
+	pipeline := []bson.M{
+		{
+			// Terminated containers no longer expose the secret values
+			"$match": bson.M{
+				"secrets.0":  bson.M{"$exists": true},
+				"terminated": false,
+			},
+		},
+		{
+			"$unwind": "$secrets",
+		},
+		{
+			// Lookup the referenced secret within the container namespace, keeping only service account tokens
+			"$lookup": bson.M{
+				"as":   "secret",
+				"from": collections.SecretName,
+				"let": bson.M{
+					"secretName": "$secrets",
+					"secretNS":   "$inherited.namespace",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$expr": bson.M{
+							"$and": bson.A{
+								bson.M{"$eq": bson.A{
+									"$name", "$$secretName",
+								}},
+								bson.M{"$eq": bson.A{
+									"$namespace", "$$secretNS",
+								}},
+								bson.M{"$eq": bson.A{
+									"$type", string(corev1.SecretTypeServiceAccountToken),
+								}},
+							},
+						}},
+					},
+					{
+						"$project": bson.M{
+							"service_account": 1,
+							"namespace":       1,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$unwind": "$secret",
+		},
+		{
+			// Lookup the identity of the token service account. This requires a match on namespace/name/type
+			"$lookup": bson.M{
+				"as":   "identity",
+				"from": collections.IdentityName,
+				"let": bson.M{
+					"sa":   "$secret.service_account",
+					"saNS": "$secret.namespace",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$expr": bson.M{
+							"$and": bson.A{
+								bson.M{"$eq": bson.A{
+									"$name", "$$sa",
+								}},
+								bson.M{"$eq": bson.A{
+									"$namespace", "$$saNS",
+								}},
+								bson.M{"$eq": bson.A{
+									"$type", rbacv1.ServiceAccountKind,
+								}},
+							},
+						}},
+					},
+					{
+						"$project": bson.M{
+							"_id": 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$unwind": "$identity",
+		},
+		{
+			// Several secrets (e.g env var and volume) of a container may hold a token of the same service account
+			"$group": bson.M{
+				"_id": bson.M{
+					"container_id": "$_id",
+					"identity_id":  "$identity._id",
+				},
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id":          0,
+				"container_id": "$_id.container_id",
+				"identity_id":  "$_id.identity_id",
+			},
+		},
+	}
+
+	cur, err := containers.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return adapter.MongoCursorHandler[tokenStealSecretGroup](ctx, cur, callback, complete)
+}
@@ -0,0 +1,281 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mounts that grant write access to the host /var/log directory served by the kubelet /logs endpoint
+var VarLogMountList = []primitive.Regex{
+	{Pattern: "^/$"},
+	{Pattern: "^/var$"},
+	{Pattern: "^/var/log$"},
+}
+
+func init() {
+	Register(&TokenVarLogSymlink{}, RegisterDefault)
+}
+
+type TokenVarLogSymlink struct {
+	BaseEdge
+}
+
+type tokenVarLogSymlinkGroup struct {
+	Container primitive.ObjectID `bson:"container_id" json:"container"`
+	Identity  primitive.ObjectID `bson:"identity_id" json:"identity"`
+}
+
+type tokenVarLogSymlinkCandidate struct {
+	Container      primitive.ObjectID   `bson:"_id"`
+	PodName        string               `bson:"pod_name"`
+	Namespace      string               `bson:"namespace"`
+	PermissionSets []permissionSetRules `bson:"permission_sets"`
+	Tokens         []primitive.ObjectID `bson:"tokens"`
+}
+
+func (e *TokenVarLogSymlink) Label() string {
+	return "TOKEN_VAR_LOG_SYMLINK"
+}
+
+func (e *TokenVarLogSymlink) Name() string {
+	return "TokenVarLogSymlink"
+}
+
+func (e *TokenVarLogSymlink) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*tokenVarLogSymlinkGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Container, typed.Identity)
+}
+
+// Stream finds all containers with a writable host mount of /var/log (or any parent directory) running under a service
+// account that can read its own pod logs. Such containers can symlink the host root into the log directory and read any
+// file on the node via the log endpoints, including all the service account tokens projected on the node.
+func (e *TokenVarLogSymlink) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	volumes := adapter.MongoDB(store).Collection(collections.VolumeName)
+
+	// Projected service account tokens reside under the kubelet pods directory (see libkube.ServiceAccountTokenPath)
+	tokenPath := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(libkube.KubeletPodsPath+"/")}
+
+	pipeline := []bson.M{
+		// Look for writable host mounts encapsulating the node log directory
+		{
+			"$match": bson.M{
+				"type":     shared.VolumeTypeHost,
+				"readonly": false,
+				"source": bson.M{
+					"$in": VarLogMountList,
+				},
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id":     "$container_id",
+				"node_id": bson.M{"$first": "$node_id"},
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"as":           "container",
+				"from":         collections.ContainerName,
+				"localField":   "_id",
+				"foreignField": "_id",
+			},
+		},
+		{
+			"$unwind": "$container",
+		},
+		// Terminated init and ephemeral containers cannot create the symlink
+		{
+			"$match": bson.M{
+				"container.terminated": bson.M{"$ne": true},
+			},
+		},
+		// Retrieve the service account of the container
+		{
+			"$lookup": bson.M{
+				"as":   "identity",
+				"from": collections.IdentityName,
+				"let": bson.M{
+					"saName":      "$container.inherited.service_account",
+					"saNamespace": "$container.inherited.namespace",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$and": bson.A{
+							bson.M{"$expr": bson.M{"$and": bson.A{
+								bson.M{"$eq": bson.A{"$name", "$$saName"}},
+								bson.M{"$eq": bson.A{"$namespace", "$$saNamespace"}},
+							}}},
+							bson.M{"type": "ServiceAccount"},
+						}},
+					},
+					{
+						"$project": bson.M{
+							"_id": 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$unwind": "$identity",
+		},
+		// Retrieve the permission sets bound to the service account for the evaluation of its pod logs access
+		{
+			"$lookup": bson.M{
+				"as":   "permissionSets",
+				"from": collections.RoleBindingName,
+				"let": bson.M{
+					"iid": "$identity._id",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$expr": bson.M{
+							"$in": bson.A{"$$iid", bson.M{"$ifNull": bson.A{"$subjects.identity_id", bson.A{}}}},
+						}},
+					},
+					{
+						"$lookup": bson.M{
+							"as":           "permissionSet",
+							"from":         collections.PermissionSetName,
+							"localField":   "_id",
+							"foreignField": "role_binding_id",
+						},
+					},
+					{
+						"$unwind": "$permissionSet",
+					},
+					{
+						"$replaceRoot": bson.M{
+							"newRoot": "$permissionSet",
+						},
+					},
+					{
+						"$project": bson.M{
+							"_id":           1,
+							"is_namespaced": 1,
+							"namespace":     1,
+							"rules":         1,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$match": bson.M{
+				"permissionSets": bson.M{"$ne": bson.A{}},
+			},
+		},
+		// Gather all the service account tokens projected on the same node
+		{
+			"$lookup": bson.M{
+				"as":   "tokens",
+				"from": collections.VolumeName,
+				"let": bson.M{
+					"nid": "$node_id",
+				},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{"$and": bson.A{
+							bson.M{"$expr": bson.M{
+								"$eq": bson.A{
+									"$node_id", "$$nid",
+								},
+							}},
+							bson.M{"type": shared.VolumeTypeProjected},
+							bson.M{"projected_id": bson.M{"$ne": nil}},
+							bson.M{"source": tokenPath},
+						}},
+					},
+					{
+						"$project": bson.M{
+							"projected_id": 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id":             1,
+				"pod_name":        "$container.inherited.pod_name",
+				"namespace":       "$container.inherited.namespace",
+				"permission_sets": "$permissionSets",
+				"tokens":          "$tokens.projected_id",
+			},
+		},
+	}
+
+	cur, err := volumes.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var candidate tokenVarLogSymlinkCandidate
+		err := cur.Decode(&candidate)
+		if err != nil {
+			return err
+		}
+
+		if !candidate.canReadLogs() {
+			continue
+		}
+
+		// Multiple tokens can belong to the same identity
+		seen := make(map[primitive.ObjectID]bool)
+		for _, identity := range candidate.Tokens {
+			if seen[identity] {
+				continue
+			}
+			seen[identity] = true
+
+			err := callback(ctx, &tokenVarLogSymlinkGroup{Container: candidate.Container, Identity: identity})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := cur.Err(); err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
+
+// canReadLogs returns whether the container service account can read the logs of its own pod, either via a cluster
+// wide permission set or a permission set within the pod namespace.
+func (c *tokenVarLogSymlinkCandidate) canReadLogs() bool {
+	request := libkube.ResourceRequest{Verb: "get", APIGroup: "", Resource: "pods", Subresource: "log", Name: c.PodName}
+	for i := range c.PermissionSets {
+		ps := &c.PermissionSets[i]
+		if ps.IsNamespaced && ps.Namespace != c.Namespace {
+			continue
+		}
+
+		if libkube.RulesAllow(ps.Rules, request) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&VolumeShare{}, RegisterDefault)
+}
+
+type VolumeShare struct {
+	BaseEdge
+}
+
+type volumeShareMount struct {
+	Container primitive.ObjectID `bson:"container_id" json:"container_id"`
+	ReadOnly  bool               `bson:"readonly" json:"readonly"`
+}
+
+type volumeShareGroup struct {
+	Mounts []volumeShareMount `bson:"mounts" json:"mounts"`
+}
+
+type volumeSharePair struct {
+	Writer primitive.ObjectID `bson:"writer" json:"writer"`
+	Reader primitive.ObjectID `bson:"reader" json:"reader"`
+}
+
+func (e *VolumeShare) Label() string {
+	return "VOLUME_SHARE"
+}
+
+func (e *VolumeShare) Name() string {
+	return "VolumeShare"
+}
+
+func (e *VolumeShare) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*volumeSharePair)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Writer, typed.Reader)
+}
+
+// Stream finds all the containers that mount the same directory of a node, i.e the same emptyDir volume of a pod or
+// the same hostPath (including host backed persistent volumes), and links any container able to write to the
+// directory to all the other containers reading or executing from it.
+func (e *VolumeShare) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	volumes := adapter.MongoDB(store).Collection(collections.VolumeName)
+
+	// EmptyDir volumes are identified by their kubelet directory which is unique to the pod, so a single node and
+	// source path grouping covers both volume types. Terminated containers can neither write nor read the volume.
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"type": bson.M{"$in": bson.A{shared.VolumeTypeEmptyDir, shared.VolumeTypeHost}},
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"as":           "container",
+				"from":         collections.ContainerName,
+				"localField":   "container_id",
+				"foreignField": "_id",
+			},
+		},
+		{
+			"$match": bson.M{
+				"container.terminated": bson.M{"$ne": true},
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"node_id": "$node_id",
+					"source":  "$source",
+				},
+				"mounts": bson.M{
+					"$push": bson.M{
+						"container_id": "$container_id",
+						"readonly":     "$readonly",
+					},
+				},
+			},
+		},
+		{
+			"$match": bson.M{
+				"mounts.1": bson.M{"$exists": true},
+			},
+		},
+	}
+
+	cur, err := volumes.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var entry volumeShareGroup
+		err := cur.Decode(&entry)
+		if err != nil {
+			return err
+		}
+
+		// A container can mount the same directory multiple times, so deduplicate the pairs within the group
+		pairs := make(map[volumeSharePair]struct{})
+		for _, writer := range entry.Mounts {
+			if writer.ReadOnly {
+				continue
+			}
+
+			for _, reader := range entry.Mounts {
+				// No need to create a link with itself
+				if writer.Container == reader.Container {
+					continue
+				}
+
+				pair := volumeSharePair{Writer: writer.Container, Reader: reader.Container}
+				if _, ok := pairs[pair]; ok {
+					continue
+				}
+				pairs[pair] = struct{}{}
+
+				err = callback(ctx, &pair)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := cur.Err(); err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
@@ -0,0 +1,149 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Admission webhook configuration resources. Mutating webhooks can rewrite any pod created in the cluster, while
+// validating webhooks receive every admitted object (including secrets and pod specs) and can control its admission.
+var webhookConfigurationResources = []string{
+	"mutatingwebhookconfigurations",
+	"validatingwebhookconfigurations",
+}
+
+// webhookTamperRequests returns the requests on all admission webhook configurations for the provided verb.
+func webhookTamperRequests(verb string) []libkube.ResourceRequest {
+	requests := make([]libkube.ResourceRequest, 0, len(webhookConfigurationResources))
+	for _, resource := range webhookConfigurationResources {
+		requests = append(requests, libkube.ResourceRequest{
+			Verb:     verb,
+			APIGroup: "admissionregistration.k8s.io",
+			Resource: resource,
+		})
+	}
+
+	return requests
+}
+
+func init() {
+	Register(&WebhookTamper{}, RegisterGraphMutation)
+}
+
+type WebhookTamper struct {
+	BaseEdge
+}
+
+type webhookTamperGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+func (e *WebhookTamper) Label() string {
+	return "WEBHOOK_TAMPER"
+}
+
+func (e *WebhookTamper) Name() string {
+	return "WebhookTamper"
+}
+
+func (e *WebhookTamper) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *WebhookTamper) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*webhookTamperGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *WebhookTamper) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rwt").
+				MergeV(__.Select("rwt")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on WEBHOOK_TAMPER insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				HasLabel("Node").
+				Has("class", "Node").
+				As("n").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("n").
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and can create admission webhook configurations, or update/patch
+// existing ones, including equivalent wildcard permissions. Create requests carry no resource name, while update/patch
+// permissions restricted to resource names still grant control of the named webhook configurations. Webhook
+// configurations are not namespaced so namespaced roles cannot grant such permissions.
+func (e *WebhookTamper) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	filter := bson.M{
+		"is_namespaced": false,
+	}
+
+	create := webhookTamperRequests("create")
+	modify := append(webhookTamperRequests("update"), webhookTamperRequests("patch")...)
+	requests := append(append([]libkube.ResourceRequest{}, create...), modify...)
+
+	err := streamPermissionSets(ctx, store, filter, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		if !rulesAllowAny(ps.Rules, create...) && !rulesResourceScope(ps.Rules, modify...).Allowed() {
+			return nil
+		}
+
+		return callback(ctx, &webhookTamperGroup{Role: ps.Id})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
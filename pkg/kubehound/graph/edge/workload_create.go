@@ -0,0 +1,221 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// workloadController describes a workload controller resource that creates pods from a pod template.
+type workloadController struct {
+	Kind     string
+	APIGroup string
+	Resource string
+}
+
+// Workload controllers outside of the core API group. Pods and replication controllers are handled by POD_CREATE.
+var workloadControllers = []workloadController{
+	{Kind: "DaemonSet", APIGroup: "apps", Resource: "daemonsets"},
+	{Kind: "Deployment", APIGroup: "apps", Resource: "deployments"},
+	{Kind: "ReplicaSet", APIGroup: "apps", Resource: "replicasets"},
+	{Kind: "StatefulSet", APIGroup: "apps", Resource: "statefulsets"},
+	{Kind: "CronJob", APIGroup: "batch", Resource: "cronjobs"},
+	{Kind: "Job", APIGroup: "batch", Resource: "jobs"},
+}
+
+// workloadControllerRequests returns the requests on all the workload controllers for the provided verb.
+func workloadControllerRequests(verb string) []libkube.ResourceRequest {
+	requests := make([]libkube.ResourceRequest, 0, len(workloadControllers))
+	for _, controller := range workloadControllers {
+		requests = append(requests, libkube.ResourceRequest{
+			Verb:     verb,
+			APIGroup: controller.APIGroup,
+			Resource: controller.Resource,
+		})
+	}
+
+	return requests
+}
+
+func init() {
+	Register(&WorkloadCreate{}, RegisterGraphMutation)
+}
+
+type WorkloadCreate struct {
+	BaseEdge
+}
+
+type workloadCreateGroup struct {
+	Role  primitive.ObjectID `bson:"_id" json:"role"`
+	Kinds []string           `bson:"kinds" json:"kinds"`
+}
+
+func (e *WorkloadCreate) Label() string {
+	return "WORKLOAD_CREATE"
+}
+
+func (e *WorkloadCreate) Name() string {
+	return "WorkloadCreate"
+}
+
+func (e *WorkloadCreate) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *WorkloadCreate) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*workloadCreateGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			"role": map[any]any{
+				gremlin.T.Label: vertex.PermissionSetLabel,
+				gremlin.T.Id:    rid,
+			},
+			"kinds": typed.Kinds,
+		}, nil
+	}
+
+	return map[any]any{
+		"role":  rid,
+		"kinds": typed.Kinds,
+	}, nil
+}
+
+func (e *WorkloadCreate) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rwc").
+				MergeV(__.Select("rwc").Select("role")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on WORKLOAD_CREATE insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Property("kinds", __.Select("rwc").Select("kinds")).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack. Roles
+			// are grouped by their list of workload kinds, so each group can be linked to all nodes in a single step.
+			g.V().
+				HasLabel("Node").
+				Has("class", "Node").
+				As("n")
+
+			for _, group := range groupWorkloadInserts(inserts) {
+				g.SideEffect(
+					__.V(group.roles...).
+						Has("critical", false).
+						AddE(e.Label()).
+						To("n").
+						Property("kinds", group.kinds))
+			}
+
+			g.Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+// workloadInsertGroup is a set of roles granting control over the same workload kinds.
+type workloadInsertGroup struct {
+	kinds []string
+	roles []any
+}
+
+// groupWorkloadInserts groups the processed inserts of the builder by their list of workload kinds.
+func groupWorkloadInserts(inserts []any) []*workloadInsertGroup {
+	groups := make([]*workloadInsertGroup, 0)
+	index := make(map[string]*workloadInsertGroup)
+	for _, insert := range inserts {
+		typed, ok := insert.(map[any]any)
+		if !ok {
+			continue
+		}
+
+		kinds, _ := typed["kinds"].([]string)
+		key := strings.Join(kinds, ",")
+		group, ok := index[key]
+		if !ok {
+			group = &workloadInsertGroup{kinds: kinds}
+			index[key] = group
+			groups = append(groups, group)
+		}
+
+		group.roles = append(group.roles, typed["role"])
+	}
+
+	return groups
+}
+
+// Stream finds all roles that can create a workload controller, or update/patch an existing one (thereby rewriting its
+// pod template), including equivalent wildcard permissions. Create requests carry no resource name, while update/patch
+// permissions restricted to resource names still grant control of the named controllers. All the workload kinds are
+// evaluated in a single pass and recorded on the edge. As for POD_CREATE, roles restricted to a namespace enforcing the
+// baseline or restricted pod security standards are excluded, since the pods created by the controller are subject to
+// the same admission.
+func (e *WorkloadCreate) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	enforced, err := loadPodSecurityNamespaces(ctx, store)
+	if err != nil {
+		return err
+	}
+
+	create := workloadControllerRequests("create")
+	update := workloadControllerRequests("update")
+	patch := workloadControllerRequests("patch")
+	requests := append(append(append([]libkube.ResourceRequest{}, create...), update...), patch...)
+
+	err = streamPermissionSets(ctx, store, bson.M{}, requests, func(ctx context.Context, ps *permissionSetRules) error {
+		if enforced.PreventsPrivileged(ps) {
+			return nil
+		}
+
+		kinds := make([]string, 0)
+		for i, controller := range workloadControllers {
+			if libkube.RulesAllow(ps.Rules, create[i]) || rulesResourceScope(ps.Rules, update[i], patch[i]).Allowed() {
+				kinds = append(kinds, controller.Kind)
+			}
+		}
+
+		if len(kinds) == 0 {
+			return nil
+		}
+
+		return callback(ctx, &workloadCreateGroup{Role: ps.Id, Kinds: kinds})
+	})
+	if err != nil {
+		return err
+	}
+
+	return complete(ctx)
+}
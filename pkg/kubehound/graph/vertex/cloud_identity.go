@@ -0,0 +1,47 @@
+package vertex
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+)
+
+const (
+	CloudIdentityLabel = "CloudIdentity"
+)
+
+var _ Builder = (*CloudIdentity)(nil)
+
+type CloudIdentity struct {
+	BaseVertex
+}
+
+func (v *CloudIdentity) Label() string {
+	return CloudIdentityLabel
+}
+
+func (v *CloudIdentity) Processor(ctx context.Context, entry any) (any, error) {
+	return adapter.GremlinVertexProcessor[*graph.CloudIdentity](ctx, entry)
+}
+
+func (v *CloudIdentity) Traversal() types.VertexTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal().
+			Inject(inserts).
+			Unfold().As("cids").
+			AddV(v.Label()).As("cidVtx").
+			Property("class", v.Label()). // labels are not indexed - use a mirror property
+			SideEffect(
+				__.Select("cids").
+					Unfold().As("kv").
+					Select("cidVtx").
+					Property(
+						__.Select("kv").By(Column.Keys),
+						__.Select("kv").By(Column.Values)))
+
+		return g
+	}
+}
@@ -0,0 +1,56 @@
+package vertex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudIdentity_Traversal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want types.VertexTraversal
+		data graph.CloudIdentity
+	}{
+		{
+			name: "Add CloudIdentities in JanusGraph",
+			// We set the values to all field with non default values
+			// so we are sure all are correctly propagated.
+			data: graph.CloudIdentity{
+				StoreID:      "test id",
+				Name:         "test name cloud identity",
+				IsNamespaced: true,
+				Namespace:    "lol namespace",
+				Provider:     "some provider",
+				Type:         "some type",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			v := CloudIdentity{}
+
+			g := gremlingo.GraphTraversalSource{}
+
+			vertexTraversal := v.Traversal()
+			inserts := []any{&tt.data}
+
+			traversal := vertexTraversal(&g, inserts)
+			// This is ugly but doesn't need to write to the DB
+			// This just makes sure the traversal is correctly returned with the correct values
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test id")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test name cloud identity")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "lol namespace")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "some provider")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "some type")
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package vertex
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+)
+
+const (
+	NamespaceLabel = "Namespace"
+)
+
+var _ Builder = (*Namespace)(nil)
+
+type Namespace struct {
+	BaseVertex
+}
+
+func (v *Namespace) Label() string {
+	return NamespaceLabel
+}
+
+func (v *Namespace) Processor(ctx context.Context, entry any) (any, error) {
+	return adapter.GremlinVertexProcessor[*graph.Namespace](ctx, entry)
+}
+
+func (v *Namespace) Traversal() types.VertexTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal().
+			Inject(inserts).
+			Unfold().As("nss").
+			AddV(v.Label()).As("nsVtx").
+			Property("class", v.Label()). // labels are not indexed - use a mirror property
+			SideEffect(
+				__.Select("nss").
+					Unfold().As("kv").
+					Select("nsVtx").
+					Property(
+						__.Select("kv").By(Column.Keys),
+						__.Select("kv").By(Column.Values)))
+
+		return g
+	}
+}
@@ -0,0 +1,47 @@
+package vertex
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+)
+
+const (
+	SecretLabel = "Secret"
+)
+
+var _ Builder = (*Secret)(nil)
+
+type Secret struct {
+	BaseVertex
+}
+
+func (v *Secret) Label() string {
+	return SecretLabel
+}
+
+func (v *Secret) Processor(ctx context.Context, entry any) (any, error) {
+	return adapter.GremlinVertexProcessor[*graph.Secret](ctx, entry)
+}
+
+func (v *Secret) Traversal() types.VertexTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal().
+			Inject(inserts).
+			Unfold().As("secrets").
+			AddV(v.Label()).As("secretVtx").
+			Property("class", v.Label()). // labels are not indexed - use a mirror property
+			SideEffect(
+				__.Select("secrets").
+					Unfold().As("kv").
+					Select("secretVtx").
+					Property(
+						__.Select("kv").By(Column.Keys),
+						__.Select("kv").By(Column.Values)))
+
+		return g
+	}
+}
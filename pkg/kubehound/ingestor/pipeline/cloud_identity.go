@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// processCloudIdentity will handle the ingestion pipeline for a cloud identity bound to a processed K8s object. The same
+// cloud identity can be bound to several service accounts or nodes, so lookup in cache before writing to the graph. All
+// the bindings are written to the store, referencing the first entry (which holds the graph vertex) via their canonical
+// id. The cache writer MUST be created with the test and set option.
+func processCloudIdentity(ctx context.Context, r *IngestResources, c collections.CloudIdentity,
+	v *vertex.CloudIdentity, cid *store.CloudIdentity) error {
+
+	// Async write to cache. If entry is already present only write the binding to the store.
+	ck := cachekey.CloudIdentity(cid.Provider, cid.Name)
+	err := r.writeCache(ctx, ck, cid.Id.Hex())
+	if err != nil {
+		switch e := err.(type) {
+		case *cache.OverwriteError:
+			log.Trace(ctx).Debugf("cloud identity cache entry %#v already exists, skipping vertex insert", ck)
+
+			existing, err := e.Existing().Text()
+			if err != nil {
+				return err
+			}
+
+			cid.CanonicalId, err = primitive.ObjectIDFromHex(existing)
+			if err != nil {
+				return err
+			}
+
+			return r.writeStore(ctx, c, cid)
+		default:
+			return e
+		}
+	}
+
+	// Async write cloud identity to store
+	if err := r.writeStore(ctx, c, cid); err != nil {
+		return err
+	}
+
+	// Transform store model to vertex input
+	insert, err := r.graphConvert.CloudIdentity(cid)
+	if err != nil {
+		return err
+	}
+
+	// Aysnc write to graph
+	if err := r.writeVertex(ctx, v, insert); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,11 +2,14 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/globals/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
 	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 const (
@@ -16,6 +19,7 @@ const (
 type ClusterRoleIngest struct {
 	collection collections.Role
 	r          *IngestResources
+	roles      []*rbacv1.ClusterRole // Cluster roles buffered until all are collected to resolve aggregation rules
 }
 
 var _ ObjectIngest = (*ClusterRoleIngest)(nil)
@@ -28,6 +32,7 @@ func (i *ClusterRoleIngest) Initialize(ctx context.Context, deps *Dependencies)
 	var err error
 
 	i.collection = collections.Role{}
+	i.roles = make([]*rbacv1.ClusterRole, 0)
 
 	i.r, err = CreateResources(ctx, deps,
 		WithCacheWriter(),
@@ -40,35 +45,55 @@ func (i *ClusterRoleIngest) Initialize(ctx context.Context, deps *Dependencies)
 }
 
 // streamCallback is invoked by the collector for each cluster role collected.
-// The function ingests an input cluster role into the cache/store/graph databases asynchronously.
+// The function buffers an input cluster role until all cluster roles have been collected, as the aggregation rules
+// of a cluster role can only be resolved against the full set of cluster roles.
 func (i *ClusterRoleIngest) IngestClusterRole(ctx context.Context, role types.ClusterRoleType) error {
 	if ok, err := preflight.CheckClusterRole(role); !ok {
 		return err
 	}
 
-	// Normalize K8s cluster role to store object format. Cluster roles are treated as
-	// role within our model (with IsNamespaced flag set to false).
-	o, err := i.r.storeConvert.ClusterRole(ctx, role)
-	if err != nil {
-		return err
-	}
-
-	// Async write to store
-	if err := i.r.writeStore(ctx, i.collection, o); err != nil {
-		return err
-	}
-
-	// Async write to cache
-	if err := i.r.writeCache(ctx, cachekey.Role(o.Name, o.Namespace), *o); err != nil {
-		return err
-	}
+	// Collectors may reuse the input object between calls
+	i.roles = append(i.roles, (*rbacv1.ClusterRole)(role).DeepCopy())
 
 	return nil
 }
 
 // completeCallback is invoked by the collector when all cluster roles have been streamed.
-// The function flushes all writers and waits for completion.
+// The function resolves the cluster role aggregation rules and ingests all cluster roles into the cache/store
+// databases asynchronously. It then flushes all writers and waits for completion.
 func (i *ClusterRoleIngest) Complete(ctx context.Context) error {
+	aggregated, err := libkube.AggregateClusterRoleRules(i.roles)
+	if err != nil {
+		return fmt.Errorf("resolving cluster role aggregation: %w", err)
+	}
+
+	for _, role := range i.roles {
+		// Normalize K8s cluster role to store object format. Cluster roles are treated as
+		// role within our model (with IsNamespaced flag set to false).
+		o, err := i.r.storeConvert.ClusterRole(ctx, role)
+		if err != nil {
+			return err
+		}
+
+		// Store the effective rules of aggregated cluster roles, keeping the original rules for provenance
+		if rules, ok := aggregated[role.Name]; ok {
+			o.OriginalRules = o.Rules
+			o.Rules = rules
+		}
+
+		// Async write to store
+		if err := i.r.writeStore(ctx, i.collection, o); err != nil {
+			return err
+		}
+
+		// Async write to cache
+		if err := i.r.writeCache(ctx, cachekey.Role(o.Name, o.Namespace), *o); err != nil {
+			return err
+		}
+	}
+
+	i.roles = nil
+
 	return i.r.flushWriters(ctx)
 }
 
@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+)
+
+const (
+	NetworkPolicyIngestName = "k8s-network-policy-ingest"
+)
+
+// NetworkPolicyIngest stores the ingress network policies, to be evaluated against the endpoints when building the
+// ENDPOINT_EXPLOIT edges. No graph objects are created.
+type NetworkPolicyIngest struct {
+	collection collections.NetworkPolicy
+	r          *IngestResources
+}
+
+var _ ObjectIngest = (*NetworkPolicyIngest)(nil)
+
+func (i *NetworkPolicyIngest) Name() string {
+	return NetworkPolicyIngestName
+}
+
+func (i *NetworkPolicyIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.collection = collections.NetworkPolicy{}
+
+	i.r, err = CreateResources(ctx, deps,
+		WithStoreWriter(i.collection))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// streamCallback is invoked by the collector for each network policy collected.
+// The function ingests an input network policy into the store asynchronously.
+func (i *NetworkPolicyIngest) IngestNetworkPolicy(ctx context.Context, np types.NetworkPolicyType) error {
+	if ok, err := preflight.CheckNetworkPolicy(np); !ok {
+		return err
+	}
+
+	// Normalize K8s network policy to store object format
+	o, err := i.r.storeConvert.NetworkPolicy(ctx, np)
+	if err != nil {
+		return err
+	}
+
+	// Async write to store
+	if err := i.r.writeStore(ctx, i.collection, o); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// completeCallback is invoked by the collector when all network policies have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *NetworkPolicyIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *NetworkPolicyIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamNetworkPolicies(ctx, i)
+}
+
+func (i *NetworkPolicyIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	storedb "github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb/mocks"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNetworkPolicyIngest_Pipeline(t *testing.T) {
+	ni := &NetworkPolicyIngest{}
+
+	ctx := context.Background()
+	fakeNp, err := loadTestObject[types.NetworkPolicyType]("testdata/networkpolicy.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamNetworkPolicies(ctx, ni).
+		RunAndReturn(func(ctx context.Context, i collector.NetworkPolicyIngestor) error {
+			// Fake the stream of a single network policy from the collector client
+			err := i.IngestNetworkPolicy(ctx, fakeNp)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Store setup
+	sdb := storedb.NewProvider(t)
+	sw := storedb.NewAsyncWriter(t)
+	policies := collections.NetworkPolicy{}
+	sw.EXPECT().Queue(ctx, mock.MatchedBy(func(np *store.NetworkPolicy) bool {
+		return np.Name == "test-app-ingress" && np.Namespace == "test-app" && len(np.K8.Ingress) == 1
+	})).Return(nil).Once()
+	sw.EXPECT().Flush(ctx).Return(nil)
+	sw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, policies, mock.Anything).Return(sw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+		},
+	}
+
+	// Initialize
+	err = ni.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = ni.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = ni.Close(ctx)
+	assert.NoError(t, err)
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/DataDog/KubeHound/pkg/globals/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
 	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
 	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 )
@@ -15,9 +18,11 @@ const (
 )
 
 type NodeIngest struct {
-	vertex     *vertex.Node
-	collection collections.Node
-	r          *IngestResources
+	vertex              *vertex.Node
+	vertexCloudIdentity *vertex.CloudIdentity
+	collection          collections.Node
+	cloudidentity       collections.CloudIdentity
+	r                   *IngestResources
 }
 
 var _ ObjectIngest = (*NodeIngest)(nil)
@@ -30,12 +35,16 @@ func (i *NodeIngest) Initialize(ctx context.Context, deps *Dependencies) error {
 	var err error
 
 	i.vertex = &vertex.Node{}
+	i.vertexCloudIdentity = &vertex.CloudIdentity{}
 	i.collection = collections.Node{}
+	i.cloudidentity = collections.CloudIdentity{}
 
 	i.r, err = CreateResources(ctx, deps,
-		WithCacheWriter(),
+		WithCacheWriter(cache.WithTest()),
 		WithStoreWriter(i.collection),
+		WithStoreWriter(i.cloudidentity),
 		WithGraphWriter(i.vertex),
+		WithGraphWriter(i.vertexCloudIdentity),
 		WithConverterCache())
 	if err != nil {
 		return err
@@ -77,7 +86,23 @@ func (i *NodeIngest) IngestNode(ctx context.Context, node types.NodeType) error
 		return err
 	}
 
-	return nil
+	return i.processCloudIdentity(ctx, o)
+}
+
+// processCloudIdentity will handle the ingestion pipeline for the cloud identity attached to the cloud instance
+// running a processed store node. Nodes not running on a supported cloud provider instance are skipped.
+func (i *NodeIngest) processCloudIdentity(ctx context.Context, node *store.Node) error {
+	// Normalize the node provider ID to store cloud identity object format
+	cid, err := i.r.storeConvert.InstanceCloudIdentity(ctx, node)
+	if err != nil {
+		if err == converter.ErrNoCloudInstance {
+			return nil
+		}
+
+		return err
+	}
+
+	return processCloudIdentity(ctx, i.r, i.cloudidentity, i.vertexCloudIdentity, cid)
 }
 
 // completeCallback is invoked by the collector when all nodes have been streamed.
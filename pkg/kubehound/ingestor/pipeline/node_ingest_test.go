@@ -42,9 +42,10 @@ func TestNodeIngest_Pipeline(t *testing.T) {
 	c := mockcache.NewCacheProvider(t)
 	cw := mockcache.NewAsyncWriter(t)
 	cw.EXPECT().Queue(ctx, mock.AnythingOfType("*cachekey.nodeCacheKey"), mock.AnythingOfType("string")).Return(nil).Once()
+	cw.EXPECT().Queue(ctx, mock.AnythingOfType("*cachekey.cloudIdentityCacheKey"), mock.AnythingOfType("string")).Return(nil).Once()
 	cw.EXPECT().Flush(ctx).Return(nil)
 	cw.EXPECT().Close(ctx).Return(nil)
-	c.EXPECT().BulkWriter(ctx).Return(cw, nil)
+	c.EXPECT().BulkWriter(ctx, mock.AnythingOfType("cache.WriterOption")).Return(cw, nil)
 	c.EXPECT().Get(ctx, cachekey.Identity("system:node:node-1", "")).Return(&cache.CacheResult{
 		Value: nil,
 		Err:   cache.ErrNoEntry,
@@ -68,6 +69,18 @@ func TestNodeIngest_Pipeline(t *testing.T) {
 	sw.EXPECT().Close(ctx).Return(nil)
 	sdb.EXPECT().BulkWriter(ctx, nodes, mock.Anything).Return(sw, nil)
 
+	csw := storedb.NewAsyncWriter(t)
+	cloudIdentities := collections.CloudIdentity{}
+	cidStoreId := store.ObjectID()
+	csw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.CloudIdentity")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.CloudIdentity).Id = cidStoreId
+			return nil
+		}).Once()
+	csw.EXPECT().Flush(ctx).Return(nil)
+	csw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, cloudIdentities, mock.Anything).Return(csw, nil)
+
 	// Graph setup
 	vtxInsert := map[string]any{
 		"compromised":  float64(0), // weird conversion to float by processor
@@ -87,6 +100,23 @@ func TestNodeIngest_Pipeline(t *testing.T) {
 	gw.EXPECT().Close(ctx).Return(nil)
 	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Node"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
 
+	cidVtxInsert := map[string]any{
+		"isNamespaced": false,
+		"name":         "i-0123456789abcdef0",
+		"namespace":    "",
+		"provider":     "AWS",
+		"storeID":      cidStoreId.Hex(),
+		"type":         "Instance",
+		"app":          "",
+		"service":      "",
+		"team":         "test-team",
+	}
+	cgw := graphdb.NewAsyncVertexWriter(t)
+	cgw.EXPECT().Queue(ctx, cidVtxInsert).Return(nil).Once()
+	cgw.EXPECT().Flush(ctx).Return(nil)
+	cgw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.CloudIdentity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(cgw, nil)
+
 	deps := &Dependencies{
 		Collector: client,
 		Cache:     c,
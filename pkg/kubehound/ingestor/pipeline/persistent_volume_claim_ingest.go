@@ -0,0 +1,66 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+)
+
+const (
+	PersistentVolumeClaimIngestName = "k8s-persistent-volume-claim-ingest"
+)
+
+// PersistentVolumeClaimIngest caches the name of the persistent volume bound to each persistent volume claim, to be
+// resolved from the pod volumes. No store or graph objects are created.
+type PersistentVolumeClaimIngest struct {
+	r *IngestResources
+}
+
+var _ ObjectIngest = (*PersistentVolumeClaimIngest)(nil)
+
+func (i *PersistentVolumeClaimIngest) Name() string {
+	return PersistentVolumeClaimIngestName
+}
+
+func (i *PersistentVolumeClaimIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.r, err = CreateResources(ctx, deps,
+		WithCacheWriter())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// streamCallback is invoked by the collector for each persistent volume claim collected.
+// The function ingests an input persistent volume claim into the cache asynchronously.
+func (i *PersistentVolumeClaimIngest) IngestPersistentVolumeClaim(ctx context.Context, pvc types.PersistentVolumeClaimType) error {
+	if ok, err := preflight.CheckPersistentVolumeClaim(pvc); !ok {
+		return err
+	}
+
+	// Async write to cache
+	if err := i.r.writeCache(ctx, cachekey.PersistentVolumeClaim(pvc.Name, pvc.Namespace), pvc.Spec.VolumeName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// completeCallback is invoked by the collector when all persistent volume claims have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *PersistentVolumeClaimIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *PersistentVolumeClaimIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamPersistentVolumeClaims(ctx, i)
+}
+
+func (i *PersistentVolumeClaimIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
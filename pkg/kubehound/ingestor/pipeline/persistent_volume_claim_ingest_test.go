@@ -0,0 +1,65 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	cache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentVolumeClaimIngest_Pipeline(t *testing.T) {
+	pi := &PersistentVolumeClaimIngest{}
+
+	ctx := context.Background()
+	fakePvc, err := loadTestObject[types.PersistentVolumeClaimType]("testdata/persistentvolumeclaim.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamPersistentVolumeClaims(ctx, pi).
+		RunAndReturn(func(ctx context.Context, i collector.PersistentVolumeClaimIngestor) error {
+			// Fake the stream of a single persistent volume claim from the collector client
+			err := i.IngestPersistentVolumeClaim(ctx, fakePvc)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := cache.NewCacheProvider(t)
+	cw := cache.NewAsyncWriter(t)
+
+	cw.EXPECT().Queue(ctx, cachekey.PersistentVolumeClaim("data-app-0", "test-app"), "local-pv-1").Return(nil).Once()
+	cw.EXPECT().Flush(ctx).Return(nil)
+	cw.EXPECT().Close(ctx).Return(nil)
+	c.EXPECT().BulkWriter(ctx).Return(cw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+		},
+	}
+
+	// Initialize
+	err = pi.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = pi.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = pi.Close(ctx)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+)
+
+const (
+	PersistentVolumeIngestName = "k8s-persistent-volume-ingest"
+)
+
+// PersistentVolumeIngest caches the persistent volumes backed by the host node, to be resolved from the persistent
+// volume claims of the pod volumes. No store or graph objects are created.
+type PersistentVolumeIngest struct {
+	r *IngestResources
+}
+
+var _ ObjectIngest = (*PersistentVolumeIngest)(nil)
+
+func (i *PersistentVolumeIngest) Name() string {
+	return PersistentVolumeIngestName
+}
+
+func (i *PersistentVolumeIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.r, err = CreateResources(ctx, deps,
+		WithCacheWriter())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// streamCallback is invoked by the collector for each persistent volume collected.
+// The function ingests an input persistent volume into the cache asynchronously.
+func (i *PersistentVolumeIngest) IngestPersistentVolume(ctx context.Context, pv types.PersistentVolumeType) error {
+	if ok, err := preflight.CheckPersistentVolume(pv); !ok {
+		return err
+	}
+
+	// Normalize K8s persistent volume to store object format
+	o, err := i.r.storeConvert.PersistentVolume(ctx, pv)
+	if err != nil {
+		log.Trace(ctx).Debugf("process persistent volume %s: %v (continuing)", pv.Name, err)
+		return nil
+	}
+
+	// Async write to cache
+	if err := i.r.writeCache(ctx, cachekey.PersistentVolume(o.Name), *o); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// completeCallback is invoked by the collector when all persistent volumes have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *PersistentVolumeIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *PersistentVolumeIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamPersistentVolumes(ctx, i)
+}
+
+func (i *PersistentVolumeIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	cache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentVolumeIngest_Pipeline(t *testing.T) {
+	pi := &PersistentVolumeIngest{}
+
+	ctx := context.Background()
+	fakePv, err := loadTestObject[types.PersistentVolumeType]("testdata/persistentvolume.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamPersistentVolumes(ctx, pi).
+		RunAndReturn(func(ctx context.Context, i collector.PersistentVolumeIngestor) error {
+			// Fake the stream of a single persistent volume from the collector client
+			err := i.IngestPersistentVolume(ctx, fakePv)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := cache.NewCacheProvider(t)
+	cw := cache.NewAsyncWriter(t)
+
+	pv := store.PersistentVolume{
+		Name:         "local-pv-1",
+		Type:         "HostPath",
+		SourcePath:   "/mnt/disks/ssd1",
+		StorageClass: "local-storage",
+	}
+	cw.EXPECT().Queue(ctx, cachekey.PersistentVolume("local-pv-1"), pv).Return(nil).Once()
+	cw.EXPECT().Flush(ctx).Return(nil)
+	cw.EXPECT().Close(ctx).Return(nil)
+	c.EXPECT().BulkWriter(ctx).Return(cw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+		},
+	}
+
+	// Initialize
+	err = pi.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = pi.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = pi.Close(ctx)
+	assert.NoError(t, err)
+}
@@ -45,7 +45,7 @@ func (i *PodIngest) Initialize(ctx context.Context, deps *Dependencies) error {
 
 	//
 	// Pods will create other objects such as volumes (from the pod volume mount list) and containers
-	// from the (container/init container lists). As such we need to intialize a list of the writers we need.
+	// from the (container/init container/ephemeral container lists). As such we need to intialize a list of the writers we need.
 	//
 
 	i.v = []vertex.Builder{
@@ -261,9 +261,24 @@ func (i *PodIngest) IngestPod(ctx context.Context, pod types.PodType) error {
 		}
 	}
 
-	// Currently do not process init containers. Any interesting identity they are running under will be the same as the container since
-	// service accounts are defined at a pod level (although this may change in future K8s releases). Any interesting properties of the
-	// container will be ephemeral, making any exploitation very complex. Thus we do not include init containers within our graph.
+	// Handle init containers. Service accounts are defined at a pod level, however init containers can hold host
+	// mounts and capabilities not granted to the main containers (e.g privileged containers tuning the node).
+	for _, container := range pod.Spec.InitContainers {
+		c := container
+		err := i.processContainer(ctx, sp, &c)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Handle ephemeral containers (e.g debug containers added via kubectl debug)
+	for _, container := range pod.Spec.EphemeralContainers {
+		c := corev1.Container(container.EphemeralContainerCommon)
+		err := i.processContainer(ctx, sp, &c)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -150,6 +150,7 @@ func TestPodIngest_Pipeline(t *testing.T) {
 		"hostPid":      false,
 		"image":        "dockerhub.com/elasticsearch:latest",
 		"name":         "elasticsearch",
+		"type":         "Regular",
 		"node":         "test-node.ec2.internal",
 		"pod":          "app-monitors-client-78cb6d7899-j2rjp",
 		"ports":        []any{"9200"},
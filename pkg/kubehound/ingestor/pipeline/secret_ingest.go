@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+)
+
+const (
+	SecretIngestName = "k8s-secret-ingest"
+)
+
+// SecretIngest ingests the metadata of the secrets, to point the token listing edges at concrete service account token
+// secrets. As secrets are sensitive, the ingest is opt-in via the collector configuration.
+type SecretIngest struct {
+	vertex     *vertex.Secret
+	collection collections.Secret
+	enabled    bool
+	r          *IngestResources
+}
+
+var _ ObjectIngest = (*SecretIngest)(nil)
+
+func (i *SecretIngest) Name() string {
+	return SecretIngestName
+}
+
+func (i *SecretIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.vertex = &vertex.Secret{}
+	i.collection = collections.Secret{}
+	i.enabled = deps.Config.Collector.Secrets
+
+	i.r, err = CreateResources(ctx, deps,
+		WithStoreWriter(i.collection),
+		WithGraphWriter(i.vertex))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// streamCallback is invoked by the collector for each secret collected.
+// The function ingests the metadata of an input secret into the store/graph asynchronously.
+func (i *SecretIngest) IngestSecret(ctx context.Context, secret types.SecretType) error {
+	if ok, err := preflight.CheckSecret(secret); !ok {
+		return err
+	}
+
+	// Normalize K8s secret to store object format
+	o, err := i.r.storeConvert.Secret(ctx, secret)
+	if err != nil {
+		return err
+	}
+
+	// Async write to store
+	if err := i.r.writeStore(ctx, i.collection, o); err != nil {
+		return err
+	}
+
+	// Transform store model to vertex input
+	insert, err := i.r.graphConvert.Secret(o)
+	if err != nil {
+		return err
+	}
+
+	// Aysnc write to graph
+	if err := i.r.writeVertex(ctx, i.vertex, insert); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// completeCallback is invoked by the collector when all secrets have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *SecretIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *SecretIngest) Run(ctx context.Context) error {
+	if !i.enabled {
+		log.Trace(ctx).Infof("Collection of secrets metadata is not enabled, skipping %s", i.Name())
+		return nil
+	}
+
+	return i.r.collect.StreamSecrets(ctx, i)
+}
+
+func (i *SecretIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+)
+
+const (
+	ServiceAccountIngestName = "k8s-service-account-ingest"
+)
+
+type ServiceAccountIngest struct {
+	vertexIdentity      *vertex.Identity
+	vertexCloudIdentity *vertex.CloudIdentity
+	identity            collections.Identity
+	cloudidentity       collections.CloudIdentity
+	r                   *IngestResources
+}
+
+var _ ObjectIngest = (*ServiceAccountIngest)(nil)
+
+func (i *ServiceAccountIngest) Name() string {
+	return ServiceAccountIngestName
+}
+
+func (i *ServiceAccountIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.vertexIdentity = &vertex.Identity{}
+	i.vertexCloudIdentity = &vertex.CloudIdentity{}
+	i.identity = collections.Identity{}
+	i.cloudidentity = collections.CloudIdentity{}
+
+	i.r, err = CreateResources(ctx, deps,
+		WithCacheWriter(cache.WithTest()),
+		WithStoreWriter(i.identity),
+		WithStoreWriter(i.cloudidentity),
+		WithGraphWriter(i.vertexIdentity),
+		WithGraphWriter(i.vertexCloudIdentity))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// processIdentity will handle the ingestion pipeline for the identity of a processed K8s service account input.
+// Identities are primarily created from role binding subjects (see RoleBindingIngest) which are processed beforehand,
+// so lookup in cache before writing to the store. This ensures service accounts bound to a cloud identity without any
+// role binding are still reachable from the pods using them.
+func (i *ServiceAccountIngest) processIdentity(ctx context.Context, sa types.ServiceAccountType) error {
+	// Normalize K8s service account to store identity object format
+	sid, err := i.r.storeConvert.ServiceAccountIdentity(ctx, sa)
+	if err != nil {
+		return err
+	}
+
+	// Async write to cache. If entry is already present skip further processing.
+	ck := cachekey.Identity(sid.Name, sid.Namespace)
+	err = i.r.writeCache(ctx, ck, sid.Id.Hex())
+	if err != nil {
+		switch e := err.(type) {
+		case *cache.OverwriteError:
+			log.Trace(ctx).Debugf("identity cache entry %#v already exists, skipping inserts", ck)
+			return nil
+		default:
+			return e
+		}
+	}
+
+	// Async write identity to store
+	if err := i.r.writeStore(ctx, i.identity, sid); err != nil {
+		return err
+	}
+
+	// Transform store model to vertex input
+	insert, err := i.r.graphConvert.Identity(sid)
+	if err != nil {
+		return err
+	}
+
+	// Aysnc write to graph
+	if err := i.r.writeVertex(ctx, i.vertexIdentity, insert); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// streamCallback is invoked by the collector for each service account collected.
+// The function ingests the identity of a service account bound to a cloud provider workload identity, as well as
+// the cloud identities it grants access to, into the store/graph.
+func (i *ServiceAccountIngest) IngestServiceAccount(ctx context.Context, sa types.ServiceAccountType) error {
+	if ok, err := preflight.CheckServiceAccount(sa); !ok {
+		return err
+	}
+
+	// Normalize the service account workload identity annotations to store object format
+	cids, err := i.r.storeConvert.WorkloadCloudIdentities(ctx, sa)
+	if err != nil {
+		return err
+	}
+
+	// Service accounts without any cloud identity (e.g the default service account of each namespace) are only
+	// ingested as identities via the role bindings referencing them.
+	if len(cids) == 0 {
+		return nil
+	}
+
+	if err := i.processIdentity(ctx, sa); err != nil {
+		return err
+	}
+
+	for _, cid := range cids {
+		if err := processCloudIdentity(ctx, i.r, i.cloudidentity, i.vertexCloudIdentity, cid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// completeCallback is invoked by the collector when all service accounts have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *ServiceAccountIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *ServiceAccountIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamServiceAccounts(ctx, i)
+}
+
+func (i *ServiceAccountIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
@@ -0,0 +1,291 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	mockcache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	graphdb "github.com/DataDog/KubeHound/pkg/kubehound/storage/graphdb/mocks"
+	storedb "github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb/mocks"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServiceAccountIngest_Pipeline(t *testing.T) {
+	si := &ServiceAccountIngest{}
+
+	ctx := context.Background()
+	fakeSa, err := loadTestObject[types.ServiceAccountType]("testdata/serviceaccount.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamServiceAccounts(ctx, si).
+		RunAndReturn(func(ctx context.Context, i collector.ServiceAccountIngestor) error {
+			// Fake the stream of a single service account from the collector client
+			err := i.IngestServiceAccount(ctx, fakeSa)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := mockcache.NewCacheProvider(t)
+	cw := mockcache.NewAsyncWriter(t)
+	cw.EXPECT().Queue(ctx, mock.AnythingOfType("*cachekey.identityCacheKey"), mock.AnythingOfType("string")).Return(nil).Once()
+	cw.EXPECT().Queue(ctx, mock.AnythingOfType("*cachekey.cloudIdentityCacheKey"), mock.AnythingOfType("string")).Return(nil).Once()
+	cw.EXPECT().Flush(ctx).Return(nil)
+	cw.EXPECT().Close(ctx).Return(nil)
+	c.EXPECT().BulkWriter(ctx, mock.AnythingOfType("cache.WriterOption")).Return(cw, nil)
+
+	// Store setup - identities
+	sdb := storedb.NewProvider(t)
+	isw := storedb.NewAsyncWriter(t)
+	identities := collections.Identity{}
+	storeId := store.ObjectID()
+	isw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.Identity")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.Identity).Id = storeId
+			return nil
+		}).Once()
+	isw.EXPECT().Flush(ctx).Return(nil)
+	isw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, identities, mock.Anything).Return(isw, nil)
+
+	// Store setup - cloud identities
+	csw := storedb.NewAsyncWriter(t)
+	cloudIdentities := collections.CloudIdentity{}
+	cidStoreId := store.ObjectID()
+	csw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.CloudIdentity")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.CloudIdentity).Id = cidStoreId
+			return nil
+		}).Once()
+	csw.EXPECT().Flush(ctx).Return(nil)
+	csw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, cloudIdentities, mock.Anything).Return(csw, nil)
+
+	// Graph setup
+	vtxInsert := map[string]any{
+		"isNamespaced": true,
+		"critical":     false,
+		"name":         "app-monitors",
+		"namespace":    "test-app",
+		"storeID":      storeId.Hex(),
+		"type":         "ServiceAccount",
+		"team":         "test-team",
+		"app":          "test-app",
+		"service":      "test-service",
+	}
+	gdb := graphdb.NewProvider(t)
+	gw := graphdb.NewAsyncVertexWriter(t)
+	gw.EXPECT().Queue(ctx, vtxInsert).Return(nil).Once()
+	gw.EXPECT().Flush(ctx).Return(nil)
+	gw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Identity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
+
+	cidVtxInsert := map[string]any{
+		"isNamespaced": false,
+		"name":         "arn:aws:iam::123456789012:role/test-app-role",
+		"namespace":    "",
+		"provider":     "AWS",
+		"storeID":      cidStoreId.Hex(),
+		"type":         "Workload",
+		"team":         "test-team",
+		"app":          "test-app",
+		"service":      "test-service",
+	}
+	cgw := graphdb.NewAsyncVertexWriter(t)
+	cgw.EXPECT().Queue(ctx, cidVtxInsert).Return(nil).Once()
+	cgw.EXPECT().Flush(ctx).Return(nil)
+	cgw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.CloudIdentity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(cgw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		GraphDB:   gdb,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+		},
+	}
+
+	// Initialize
+	err = si.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = si.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = si.Close(ctx)
+	assert.NoError(t, err)
+}
+
+func TestServiceAccountIngest_NoCloudIdentity(t *testing.T) {
+	si := &ServiceAccountIngest{}
+
+	ctx := context.Background()
+	fakeSa, err := loadTestObject[types.ServiceAccountType]("testdata/serviceaccount.json")
+	assert.NoError(t, err)
+
+	// Service accounts without workload identity annotations (e.g default) must not create any identity
+	fakeSa.Annotations = nil
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamServiceAccounts(ctx, si).
+		RunAndReturn(func(ctx context.Context, i collector.ServiceAccountIngestor) error {
+			err := i.IngestServiceAccount(ctx, fakeSa)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := mockcache.NewCacheProvider(t)
+	cw := mockcache.NewAsyncWriter(t)
+	cw.EXPECT().Flush(ctx).Return(nil)
+	cw.EXPECT().Close(ctx).Return(nil)
+	c.EXPECT().BulkWriter(ctx, mock.AnythingOfType("cache.WriterOption")).Return(cw, nil)
+
+	// Store setup
+	sdb := storedb.NewProvider(t)
+	isw := storedb.NewAsyncWriter(t)
+	isw.EXPECT().Flush(ctx).Return(nil)
+	isw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, collections.Identity{}, mock.Anything).Return(isw, nil)
+	csw := storedb.NewAsyncWriter(t)
+	csw.EXPECT().Flush(ctx).Return(nil)
+	csw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, collections.CloudIdentity{}, mock.Anything).Return(csw, nil)
+
+	// Graph setup
+	gdb := graphdb.NewProvider(t)
+	gw := graphdb.NewAsyncVertexWriter(t)
+	gw.EXPECT().Flush(ctx).Return(nil)
+	gw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Identity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
+	cgw := graphdb.NewAsyncVertexWriter(t)
+	cgw.EXPECT().Flush(ctx).Return(nil)
+	cgw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.CloudIdentity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(cgw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		GraphDB:   gdb,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+		},
+	}
+
+	err = si.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	err = si.Run(ctx)
+	assert.NoError(t, err)
+
+	err = si.Close(ctx)
+	assert.NoError(t, err)
+}
+
+func TestServiceAccountIngest_SharedCloudIdentity(t *testing.T) {
+	si := &ServiceAccountIngest{}
+
+	ctx := context.Background()
+	fakeSa, err := loadTestObject[types.ServiceAccountType]("testdata/serviceaccount.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamServiceAccounts(ctx, si).
+		RunAndReturn(func(ctx context.Context, i collector.ServiceAccountIngestor) error {
+			err := i.IngestServiceAccount(ctx, fakeSa)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup - the cloud identity was already ingested from another service account bound to the same role
+	c := mockcache.NewCacheProvider(t)
+	cw := mockcache.NewAsyncWriter(t)
+	canonicalId := store.ObjectID()
+	cw.EXPECT().Queue(ctx, mock.AnythingOfType("*cachekey.identityCacheKey"), mock.AnythingOfType("string")).Return(nil).Once()
+	cw.EXPECT().Queue(ctx, cachekey.CloudIdentity("AWS", "arn:aws:iam::123456789012:role/test-app-role"), mock.AnythingOfType("string")).
+		Return(cache.NewOverwriteError(&cache.CacheResult{Value: canonicalId.Hex()})).Once()
+	cw.EXPECT().Flush(ctx).Return(nil)
+	cw.EXPECT().Close(ctx).Return(nil)
+	c.EXPECT().BulkWriter(ctx, mock.AnythingOfType("cache.WriterOption")).Return(cw, nil)
+
+	// Store setup - the binding is written to the store, referencing the existing cloud identity
+	sdb := storedb.NewProvider(t)
+	isw := storedb.NewAsyncWriter(t)
+	isw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.Identity")).Return(nil).Once()
+	isw.EXPECT().Flush(ctx).Return(nil)
+	isw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, collections.Identity{}, mock.Anything).Return(isw, nil)
+	csw := storedb.NewAsyncWriter(t)
+	csw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.CloudIdentity")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			cid := i.(*store.CloudIdentity)
+			assert.Equal(t, canonicalId, cid.CanonicalId)
+			assert.NotEqual(t, canonicalId, cid.Id)
+
+			return nil
+		}).Once()
+	csw.EXPECT().Flush(ctx).Return(nil)
+	csw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, collections.CloudIdentity{}, mock.Anything).Return(csw, nil)
+
+	// Graph setup - no cloud identity vertex is created
+	gdb := graphdb.NewProvider(t)
+	gw := graphdb.NewAsyncVertexWriter(t)
+	gw.EXPECT().Queue(ctx, mock.Anything).Return(nil).Once()
+	gw.EXPECT().Flush(ctx).Return(nil)
+	gw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Identity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
+	cgw := graphdb.NewAsyncVertexWriter(t)
+	cgw.EXPECT().Flush(ctx).Return(nil)
+	cgw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.CloudIdentity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(cgw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		GraphDB:   gdb,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+		},
+	}
+
+	err = si.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	err = si.Run(ctx)
+	assert.NoError(t, err)
+
+	err = si.Close(ctx)
+	assert.NoError(t, err)
+}
@@ -50,6 +50,12 @@ func ingestSequence() []pipeline.Sequence {
 					Ingests: []pipeline.ObjectIngest{
 						&pipeline.NodeIngest{},
 						&pipeline.EndpointIngest{},
+						&pipeline.PersistentVolumeIngest{},
+						&pipeline.PersistentVolumeClaimIngest{},
+						&pipeline.ServiceAccountIngest{},
+						&pipeline.NetworkPolicyIngest{},
+						&pipeline.NamespaceIngest{},
+						&pipeline.SecretIngest{},
 					},
 				},
 				{
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/telemetry/log"
 )
 
@@ -109,3 +110,76 @@ func CheckEndpoint(ep types.EndpointType) (bool, error) {
 
 	return true, nil
 }
+
+// CheckPersistentVolume checks an input K8s persistent volume object and reports whether it should be ingested.
+func CheckPersistentVolume(pv types.PersistentVolumeType) (bool, error) {
+	if pv == nil {
+		return false, errors.New("nil persistent volume input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckPersistentVolumeClaim checks an input K8s persistent volume claim object and reports whether it should be ingested.
+func CheckPersistentVolumeClaim(pvc types.PersistentVolumeClaimType) (bool, error) {
+	if pvc == nil {
+		return false, errors.New("nil persistent volume claim input in preflight check")
+	}
+
+	// If the claim is not bound to a volume there is nothing to resolve
+	if pvc.Spec.VolumeName == "" {
+		log.I.Debugf("persistent volume claim %s::%s not bound to a volume, skipping ingest!",
+			pvc.Namespace, pvc.Name)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// CheckServiceAccount checks an input K8s service account object and reports whether it should be ingested.
+func CheckServiceAccount(sa types.ServiceAccountType) (bool, error) {
+	if sa == nil {
+		return false, errors.New("nil service account input in preflight check")
+	}
+
+	// Service accounts are only ingested to bridge into a cloud provider account
+	if len(libkube.WorkloadCloudIdentities(sa)) == 0 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// CheckNetworkPolicy checks an input K8s network policy object and reports whether it should be ingested.
+func CheckNetworkPolicy(np types.NetworkPolicyType) (bool, error) {
+	if np == nil {
+		return false, errors.New("nil network policy input in preflight check")
+	}
+
+	// Only ingress policies are evaluated for endpoint reachability
+	if !libkube.NetworkPolicyIngress(&np.Spec) {
+		log.I.Debugf("network policy %s::%s does not restrict ingress traffic, skipping ingest!",
+			np.Namespace, np.Name)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// CheckNamespace checks an input K8s namespace object and reports whether it should be ingested.
+func CheckNamespace(ns types.NamespaceType) (bool, error) {
+	if ns == nil {
+		return false, errors.New("nil namespace input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckSecret checks an input K8s secret object and reports whether it should be ingested.
+func CheckSecret(secret types.SecretType) (bool, error) {
+	if secret == nil {
+		return false, errors.New("nil secret input in preflight check")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,115 @@
+package libkube
+
+import (
+	"reflect"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AggregateClusterRoleRules resolves the aggregation rules of the provided cluster roles and returns the effective
+// rules of every aggregated cluster role, indexed by name. Cluster roles without an aggregation rule are not included.
+// The effective rules are the union of the rules of all the cluster roles selected by the aggregation rule (sorted by
+// name as done by the K8s clusterrole aggregation controller) and of the rules already set on the aggregated role, as
+// these are populated by the API server on a live cluster. Nested aggregations are resolved recursively.
+// See reference for details: https://kubernetes.io/docs/reference/access-authn-authz/rbac/#aggregated-clusterroles
+func AggregateClusterRoleRules(roles []*rbacv1.ClusterRole) (map[string][]rbacv1.PolicyRule, error) {
+	sorted := make([]*rbacv1.ClusterRole, len(roles))
+	copy(sorted, roles)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	a := &aggregator{
+		roles:     sorted,
+		effective: make(map[string][]rbacv1.PolicyRule),
+		resolving: make(map[string]bool),
+	}
+
+	for _, role := range sorted {
+		if role.AggregationRule == nil {
+			continue
+		}
+
+		if _, err := a.resolve(role); err != nil {
+			return nil, err
+		}
+	}
+
+	return a.effective, nil
+}
+
+// aggregator holds the state of a cluster role aggregation resolution.
+type aggregator struct {
+	roles     []*rbacv1.ClusterRole
+	effective map[string][]rbacv1.PolicyRule
+	resolving map[string]bool
+}
+
+// resolve returns the effective rules of a cluster role, resolving its aggregation rule if present.
+func (a *aggregator) resolve(role *rbacv1.ClusterRole) ([]rbacv1.PolicyRule, error) {
+	if role.AggregationRule == nil {
+		return role.Rules, nil
+	}
+
+	if rules, ok := a.effective[role.Name]; ok {
+		return rules, nil
+	}
+
+	// Break aggregation cycles by only considering the rules already set on the role
+	if a.resolving[role.Name] {
+		return role.Rules, nil
+	}
+	a.resolving[role.Name] = true
+	defer delete(a.resolving, role.Name)
+
+	rules := make([]rbacv1.PolicyRule, 0, len(role.Rules))
+	rules = appendUniqueRules(rules, role.Rules)
+
+	for _, selector := range role.AggregationRule.ClusterRoleSelectors {
+		selector := selector
+		s, err := metav1.LabelSelectorAsSelector(&selector)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, source := range a.roles {
+			// The aggregated role itself is never considered as an aggregation source
+			if source.Name == role.Name || !s.Matches(labels.Set(source.Labels)) {
+				continue
+			}
+
+			sourceRules, err := a.resolve(source)
+			if err != nil {
+				return nil, err
+			}
+
+			rules = appendUniqueRules(rules, sourceRules)
+		}
+	}
+
+	a.effective[role.Name] = rules
+
+	return rules, nil
+}
+
+// appendUniqueRules appends the rules that are not already present in the destination.
+func appendUniqueRules(dst []rbacv1.PolicyRule, rules []rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	for _, rule := range rules {
+		exists := false
+		for _, existing := range dst {
+			if reflect.DeepEqual(existing, rule) {
+				exists = true
+				break
+			}
+		}
+
+		if !exists {
+			dst = append(dst, rule)
+		}
+	}
+
+	return dst
+}
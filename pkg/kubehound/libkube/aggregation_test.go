@@ -0,0 +1,108 @@
+package libkube
+
+import (
+	"reflect"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testClusterRole(name string, lbls map[string]string, aggregate map[string]string, rules ...rbacv1.PolicyRule) *rbacv1.ClusterRole {
+	role := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: lbls,
+		},
+		Rules: rules,
+	}
+
+	if aggregate != nil {
+		role.AggregationRule = &rbacv1.AggregationRule{
+			ClusterRoleSelectors: []metav1.LabelSelector{
+				{MatchLabels: aggregate},
+			},
+		}
+	}
+
+	return role
+}
+
+func TestAggregateClusterRoleRules(t *testing.T) {
+	getPods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}
+	listPods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"list"}}
+	execPods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}
+
+	tests := []struct {
+		name  string
+		roles []*rbacv1.ClusterRole
+		want  map[string][]rbacv1.PolicyRule
+	}{
+		{
+			name: "no aggregation",
+			roles: []*rbacv1.ClusterRole{
+				testClusterRole("reader", nil, nil, getPods),
+			},
+			want: map[string][]rbacv1.PolicyRule{},
+		},
+		{
+			name: "aggregation from file snapshot",
+			roles: []*rbacv1.ClusterRole{
+				testClusterRole("view", nil, map[string]string{"aggregate-to-view": "true"}),
+				testClusterRole("get", map[string]string{"aggregate-to-view": "true"}, nil, getPods),
+				testClusterRole("list", map[string]string{"aggregate-to-view": "true"}, nil, listPods, getPods),
+				testClusterRole("exec", map[string]string{"aggregate-to-edit": "true"}, nil, execPods),
+			},
+			want: map[string][]rbacv1.PolicyRule{
+				"view": {getPods, listPods},
+			},
+		},
+		{
+			name: "aggregation already populated by the API server",
+			roles: []*rbacv1.ClusterRole{
+				testClusterRole("view", nil, map[string]string{"aggregate-to-view": "true"}, getPods),
+				testClusterRole("get", map[string]string{"aggregate-to-view": "true"}, nil, getPods),
+			},
+			want: map[string][]rbacv1.PolicyRule{
+				"view": {getPods},
+			},
+		},
+		{
+			name: "nested aggregation",
+			roles: []*rbacv1.ClusterRole{
+				testClusterRole("edit", nil, map[string]string{"aggregate-to-edit": "true"}),
+				testClusterRole("view", map[string]string{"aggregate-to-edit": "true"}, map[string]string{"aggregate-to-view": "true"}),
+				testClusterRole("get", map[string]string{"aggregate-to-view": "true"}, nil, getPods),
+				testClusterRole("exec", map[string]string{"aggregate-to-edit": "true"}, nil, execPods),
+			},
+			want: map[string][]rbacv1.PolicyRule{
+				"edit": {execPods, getPods},
+				"view": {getPods},
+			},
+		},
+		{
+			name: "aggregation cycle",
+			roles: []*rbacv1.ClusterRole{
+				testClusterRole("a", map[string]string{"to-b": "true"}, map[string]string{"to-a": "true"}, getPods),
+				testClusterRole("b", map[string]string{"to-a": "true"}, map[string]string{"to-b": "true"}, listPods),
+			},
+			want: map[string][]rbacv1.PolicyRule{
+				"a": {getPods, listPods},
+				"b": {listPods, getPods},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AggregateClusterRoleRules(tt.roles)
+			if err != nil {
+				t.Errorf("AggregateClusterRoleRules() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AggregateClusterRoleRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
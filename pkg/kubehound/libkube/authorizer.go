@@ -0,0 +1,220 @@
+package libkube
+
+import (
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// ResourceRequest holds the attributes of a K8s API resource request relevant to RBAC authorization.
+// See reference for details: https://kubernetes.io/docs/reference/access-authn-authz/authorization/#review-your-request-attributes
+type ResourceRequest struct {
+	Verb        string
+	APIGroup    string
+	Resource    string
+	Subresource string
+	Name        string // An empty name designates a request on the resource type (create, list, watch, etc)
+}
+
+// NonResourceRequest holds the attributes of a K8s API non-resource request (e.g /healthz) relevant to RBAC authorization.
+type NonResourceRequest struct {
+	Verb string
+	Path string
+}
+
+// ResourceScope describes the set of named resources a list of RBAC rules grants access to.
+type ResourceScope struct {
+	All   bool     // Access is granted to all resources regardless of their name
+	Names []string // Names of the resources access is granted to (if not All)
+}
+
+// Allowed returns whether the scope grants access to at least one resource.
+func (s ResourceScope) Allowed() bool {
+	return s.All || len(s.Names) > 0
+}
+
+// Contains returns whether the scope grants access to the resource with the provided name.
+func (s ResourceScope) Contains(name string) bool {
+	if s.All {
+		return true
+	}
+
+	for _, n := range s.Names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CombinedResource returns the resource of the request in the <resource>/<subresource> format used by RBAC rules.
+func (r ResourceRequest) CombinedResource() string {
+	if len(r.Subresource) == 0 {
+		return r.Resource
+	}
+
+	return r.Resource + "/" + r.Subresource
+}
+
+// VerbMatches returns whether the rule grants the requested verb.
+func VerbMatches(rule *rbacv1.PolicyRule, requestedVerb string) bool {
+	for _, ruleVerb := range rule.Verbs {
+		if ruleVerb == rbacv1.VerbAll || ruleVerb == requestedVerb {
+			return true
+		}
+	}
+
+	return false
+}
+
+// APIGroupMatches returns whether the rule applies to the requested API group.
+func APIGroupMatches(rule *rbacv1.PolicyRule, requestedGroup string) bool {
+	for _, ruleGroup := range rule.APIGroups {
+		if ruleGroup == rbacv1.APIGroupAll || ruleGroup == requestedGroup {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResourceMatches returns whether the rule applies to the requested resource. The rule resource can either be a
+// wildcard, an exact match of the combined <resource>/<subresource> or a subresource wildcard (*/<subresource>).
+func ResourceMatches(rule *rbacv1.PolicyRule, combinedRequestedResource string, requestedSubresource string) bool {
+	for _, ruleResource := range rule.Resources {
+		if ruleResource == rbacv1.ResourceAll || ruleResource == combinedRequestedResource {
+			return true
+		}
+
+		if len(requestedSubresource) == 0 {
+			continue
+		}
+
+		// A rule resource of "*/subresource" matches the subresource of any resource
+		if len(ruleResource) == len(requestedSubresource)+2 &&
+			strings.HasPrefix(ruleResource, "*/") &&
+			strings.HasSuffix(ruleResource, requestedSubresource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResourceNameMatches returns whether the rule applies to the requested resource name. Rules without resource names
+// apply to all resources, while rules with resource names never apply to requests without a name.
+func ResourceNameMatches(rule *rbacv1.PolicyRule, requestedName string) bool {
+	if len(rule.ResourceNames) == 0 {
+		return true
+	}
+
+	for _, ruleName := range rule.ResourceNames {
+		if ruleName == requestedName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NonResourceURLMatches returns whether the rule applies to the requested non-resource URL. The rule URL can either
+// be a wildcard, an exact match or a prefix terminated by a wildcard (e.g /healthz/*).
+func NonResourceURLMatches(rule *rbacv1.PolicyRule, requestedURL string) bool {
+	for _, ruleURL := range rule.NonResourceURLs {
+		if ruleURL == rbacv1.NonResourceAll || ruleURL == requestedURL {
+			return true
+		}
+
+		if strings.HasSuffix(ruleURL, "*") && strings.HasPrefix(requestedURL, strings.TrimRight(ruleURL, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RuleAllows returns whether the rule grants the resource request.
+func RuleAllows(rule *rbacv1.PolicyRule, request ResourceRequest) bool {
+	return VerbMatches(rule, request.Verb) &&
+		APIGroupMatches(rule, request.APIGroup) &&
+		ResourceMatches(rule, request.CombinedResource(), request.Subresource) &&
+		ResourceNameMatches(rule, request.Name)
+}
+
+// RuleAllowsNonResource returns whether the rule grants the non-resource request.
+func RuleAllowsNonResource(rule *rbacv1.PolicyRule, request NonResourceRequest) bool {
+	return VerbMatches(rule, request.Verb) && NonResourceURLMatches(rule, request.Path)
+}
+
+// RulesAllow returns whether any of the rules grants the resource request.
+func RulesAllow(rules []rbacv1.PolicyRule, request ResourceRequest) bool {
+	for i := range rules {
+		if RuleAllows(&rules[i], request) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RulesAllowNonResource returns whether any of the rules grants the non-resource request.
+func RulesAllowNonResource(rules []rbacv1.PolicyRule, request NonResourceRequest) bool {
+	for i := range rules {
+		if RuleAllowsNonResource(&rules[i], request) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RulesResourceScope returns the scope of the named resources the rules grant the request on. The name of the request
+// is ignored.
+func RulesResourceScope(rules []rbacv1.PolicyRule, request ResourceRequest) ResourceScope {
+	scope := ResourceScope{}
+	seen := make(map[string]bool)
+
+	for i := range rules {
+		rule := &rules[i]
+		if !VerbMatches(rule, request.Verb) ||
+			!APIGroupMatches(rule, request.APIGroup) ||
+			!ResourceMatches(rule, request.CombinedResource(), request.Subresource) {
+			continue
+		}
+
+		if len(rule.ResourceNames) == 0 {
+			return ResourceScope{All: true}
+		}
+
+		for _, name := range rule.ResourceNames {
+			if !seen[name] {
+				seen[name] = true
+				scope.Names = append(scope.Names, name)
+			}
+		}
+	}
+
+	return scope
+}
+
+// MergeResourceScopes returns the union of the provided scopes.
+func MergeResourceScopes(scopes ...ResourceScope) ResourceScope {
+	merged := ResourceScope{}
+	seen := make(map[string]bool)
+
+	for _, scope := range scopes {
+		if scope.All {
+			return ResourceScope{All: true}
+		}
+
+		for _, name := range scope.Names {
+			if !seen[name] {
+				seen[name] = true
+				merged.Names = append(merged.Names, name)
+			}
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,178 @@
+package libkube
+
+import (
+	"reflect"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestRuleAllows(t *testing.T) {
+	podExec := ResourceRequest{Verb: "create", APIGroup: "", Resource: "pods", Subresource: "exec", Name: "target"}
+	tests := []struct {
+		name    string
+		rule    rbacv1.PolicyRule
+		request ResourceRequest
+		want    bool
+	}{
+		{
+			name:    "exact match",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+			request: podExec,
+			want:    true,
+		},
+		{
+			name:    "wildcards",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			request: podExec,
+			want:    true,
+		},
+		{
+			name:    "subresource wildcard",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"*/exec"}, Verbs: []string{"create"}},
+			request: podExec,
+			want:    true,
+		},
+		{
+			name:    "parent resource does not grant subresource",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"create"}},
+			request: podExec,
+			want:    false,
+		},
+		{
+			name:    "resource prefix wildcard is not supported",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/*"}, Verbs: []string{"create"}},
+			request: podExec,
+			want:    false,
+		},
+		{
+			name:    "api group mismatch",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+			request: podExec,
+			want:    false,
+		},
+		{
+			name:    "verb mismatch",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"get"}},
+			request: podExec,
+			want:    false,
+		},
+		{
+			name: "resource name match",
+			rule: rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"},
+				ResourceNames: []string{"other", "target"}},
+			request: podExec,
+			want:    true,
+		},
+		{
+			name: "resource name mismatch",
+			rule: rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"},
+				ResourceNames: []string{"other"}},
+			request: podExec,
+			want:    false,
+		},
+		{
+			name: "resource names never match unnamed requests",
+			rule: rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"create"},
+				ResourceNames: []string{"target"}},
+			request: ResourceRequest{Verb: "create", APIGroup: "", Resource: "pods"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RuleAllows(&tt.rule, tt.request); got != tt.want {
+				t.Errorf("RuleAllows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleAllowsNonResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    rbacv1.PolicyRule
+		request NonResourceRequest
+		want    bool
+	}{
+		{
+			name:    "exact match",
+			rule:    rbacv1.PolicyRule{NonResourceURLs: []string{"/healthz"}, Verbs: []string{"get"}},
+			request: NonResourceRequest{Verb: "get", Path: "/healthz"},
+			want:    true,
+		},
+		{
+			name:    "wildcard",
+			rule:    rbacv1.PolicyRule{NonResourceURLs: []string{"*"}, Verbs: []string{"*"}},
+			request: NonResourceRequest{Verb: "get", Path: "/metrics"},
+			want:    true,
+		},
+		{
+			name:    "prefix match",
+			rule:    rbacv1.PolicyRule{NonResourceURLs: []string{"/healthz/*"}, Verbs: []string{"get"}},
+			request: NonResourceRequest{Verb: "get", Path: "/healthz/etcd"},
+			want:    true,
+		},
+		{
+			name:    "path mismatch",
+			rule:    rbacv1.PolicyRule{NonResourceURLs: []string{"/healthz"}, Verbs: []string{"get"}},
+			request: NonResourceRequest{Verb: "get", Path: "/metrics"},
+			want:    false,
+		},
+		{
+			name:    "resource rules do not apply",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			request: NonResourceRequest{Verb: "get", Path: "/metrics"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RuleAllowsNonResource(&tt.rule, tt.request); got != tt.want {
+				t.Errorf("RuleAllowsNonResource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulesResourceScope(t *testing.T) {
+	getSecrets := ResourceRequest{Verb: "get", APIGroup: "", Resource: "secrets"}
+	tests := []struct {
+		name  string
+		rules []rbacv1.PolicyRule
+		want  ResourceScope
+	}{
+		{
+			name:  "no matching rule",
+			rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}},
+			want:  ResourceScope{},
+		},
+		{
+			name: "named resources",
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, ResourceNames: []string{"a", "b"}},
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"*"}, ResourceNames: []string{"b", "c"}},
+			},
+			want: ResourceScope{Names: []string{"a", "b", "c"}},
+		},
+		{
+			name: "unrestricted rule supersedes named resources",
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, ResourceNames: []string{"a"}},
+				{APIGroups: []string{"*"}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+			},
+			want: ResourceScope{All: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := RulesResourceScope(tt.rules, getSecrets)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RulesResourceScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package libkube
+
+import (
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AWSRoleAnnotation binds a service account to an AWS IAM role (IAM roles for service accounts).
+	AWSRoleAnnotation = "eks.amazonaws.com/role-arn"
+
+	// GCPServiceAccountAnnotation binds a service account to a GCP service account (GKE workload identity).
+	GCPServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
+
+	// AzureClientIDAnnotation binds a service account to an Azure AD application (Azure workload identity).
+	AzureClientIDAnnotation = "azure.workload.identity/client-id"
+)
+
+// CloudIdentityRef references an identity of a cloud provider account.
+type CloudIdentityRef struct {
+	Provider string
+	Name     string
+}
+
+// workloadIdentityAnnotations maps the workload identity service account annotations to their cloud provider.
+var workloadIdentityAnnotations = []struct {
+	annotation string
+	provider   string
+}{
+	{AWSRoleAnnotation, shared.CloudProviderAWS},
+	{GCPServiceAccountAnnotation, shared.CloudProviderGCP},
+	{AzureClientIDAnnotation, shared.CloudProviderAzure},
+}
+
+// nodeProviderSchemes maps the node provider ID schemes to their cloud provider.
+var nodeProviderSchemes = map[string]string{
+	"aws":   shared.CloudProviderAWS,
+	"gce":   shared.CloudProviderGCP,
+	"azure": shared.CloudProviderAzure,
+}
+
+// WorkloadCloudIdentities returns the cloud identities bound to a service account via the workload identity
+// annotations of the supported cloud providers.
+func WorkloadCloudIdentities(sa *corev1.ServiceAccount) []CloudIdentityRef {
+	refs := make([]CloudIdentityRef, 0)
+	for _, wi := range workloadIdentityAnnotations {
+		name := strings.TrimSpace(sa.Annotations[wi.annotation])
+		if name == "" {
+			continue
+		}
+
+		refs = append(refs, CloudIdentityRef{Provider: wi.provider, Name: name})
+	}
+
+	return refs
+}
+
+// NodeCloudInstance returns a reference to the cloud instance running a node from the node provider ID (e.g
+// aws:///us-east-1a/i-0123456789abcdef0). The reference name is the instance ID (AWS), the instance name (GCP) or the
+// virtual machine name (Azure, suffixed with the instance ID for scale sets).
+func NodeCloudInstance(node *corev1.Node) (CloudIdentityRef, bool) {
+	scheme, path, found := strings.Cut(node.Spec.ProviderID, "://")
+	if !found {
+		return CloudIdentityRef{}, false
+	}
+
+	provider, ok := nodeProviderSchemes[scheme]
+	if !ok {
+		return CloudIdentityRef{}, false
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	name := segments[len(segments)-1]
+	if name == "" {
+		return CloudIdentityRef{}, false
+	}
+
+	// Azure scale set instances are identified by their index within the scale set:
+	// azure:///subscriptions/<id>/resourceGroups/<rg>/providers/Microsoft.Compute/virtualMachineScaleSets/<vmss>/virtualMachines/<index>
+	if provider == shared.CloudProviderAzure && len(segments) >= 4 &&
+		strings.EqualFold(segments[len(segments)-4], "virtualMachineScaleSets") {
+		name = segments[len(segments)-3] + "/" + name
+	}
+
+	return CloudIdentityRef{Provider: provider, Name: name}, true
+}
@@ -0,0 +1,122 @@
+package libkube
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWorkloadCloudIdentities(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []CloudIdentityRef
+	}{
+		{
+			name:        "no annotation",
+			annotations: map[string]string{"kubernetes.io/description": "test"},
+			want:        []CloudIdentityRef{},
+		},
+		{
+			name:        "AWS IAM role",
+			annotations: map[string]string{AWSRoleAnnotation: "arn:aws:iam::123456789012:role/app-role"},
+			want:        []CloudIdentityRef{{Provider: shared.CloudProviderAWS, Name: "arn:aws:iam::123456789012:role/app-role"}},
+		},
+		{
+			name: "GCP and Azure identities",
+			annotations: map[string]string{
+				GCPServiceAccountAnnotation: "app@project.iam.gserviceaccount.com",
+				AzureClientIDAnnotation:     "00000000-0000-0000-0000-000000000000",
+			},
+			want: []CloudIdentityRef{
+				{Provider: shared.CloudProviderGCP, Name: "app@project.iam.gserviceaccount.com"},
+				{Provider: shared.CloudProviderAzure, Name: "00000000-0000-0000-0000-000000000000"},
+			},
+		},
+		{
+			name:        "empty annotation",
+			annotations: map[string]string{AWSRoleAnnotation: " "},
+			want:        []CloudIdentityRef{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			if got := WorkloadCloudIdentities(sa); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WorkloadCloudIdentities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeCloudInstance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		providerID string
+		want       CloudIdentityRef
+		wantOk     bool
+	}{
+		{
+			name:       "AWS instance",
+			providerID: "aws:///us-east-1a/i-0123456789abcdef0",
+			want:       CloudIdentityRef{Provider: shared.CloudProviderAWS, Name: "i-0123456789abcdef0"},
+			wantOk:     true,
+		},
+		{
+			name:       "GCP instance",
+			providerID: "gce://my-project/us-central1-a/gke-cluster-default-pool-1234",
+			want:       CloudIdentityRef{Provider: shared.CloudProviderGCP, Name: "gke-cluster-default-pool-1234"},
+			wantOk:     true,
+		},
+		{
+			name:       "Azure virtual machine",
+			providerID: "azure:///subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/node-vm",
+			want:       CloudIdentityRef{Provider: shared.CloudProviderAzure, Name: "node-vm"},
+			wantOk:     true,
+		},
+		{
+			name:       "Azure scale set instance",
+			providerID: "azure:///subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachineScaleSets/aks-pool-vmss/virtualMachines/3",
+			want:       CloudIdentityRef{Provider: shared.CloudProviderAzure, Name: "aks-pool-vmss/3"},
+			wantOk:     true,
+		},
+		{
+			name:       "kind node",
+			providerID: "kind://docker/kubehound.test.local/kubehound.test.local-worker",
+			wantOk:     false,
+		},
+		{
+			name:       "no provider ID",
+			providerID: "",
+			wantOk:     false,
+		},
+		{
+			name:       "no instance",
+			providerID: "aws:///",
+			wantOk:     false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			node := &corev1.Node{Spec: corev1.NodeSpec{ProviderID: tt.providerID}}
+			got, ok := NodeCloudInstance(node)
+			if ok != tt.wantOk {
+				t.Errorf("NodeCloudInstance() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NodeCloudInstance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package libkube
+
+import (
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// CIDR blocks matching any IPv4 or IPv6 address.
+var anyAddressBlocks = []string{"0.0.0.0/0", "::/0"}
+
+// PolicyPort describes the destination port of a connection to evaluate against network policies.
+type PolicyPort struct {
+	Port     int32
+	Name     string
+	Protocol string
+}
+
+// PolicySource describes a pod of the cluster, as a potential source of traffic to evaluate against network policies.
+type PolicySource struct {
+	Namespace       string
+	NamespaceLabels map[string]string
+	PodLabels       map[string]string
+	PodIP           string
+}
+
+// NetworkPolicyIngress returns whether a network policy restricts the ingress traffic of the pods it selects. A policy
+// with no explicit policy types always applies to ingress traffic.
+// See reference: https://kubernetes.io/docs/reference/kubernetes-api/policy-resources/network-policy-v1/
+func NetworkPolicyIngress(spec *netv1.NetworkPolicySpec) bool {
+	if len(spec.PolicyTypes) == 0 {
+		return true
+	}
+
+	for _, t := range spec.PolicyTypes {
+		if t == netv1.PolicyTypeIngress {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NetworkPolicySelects returns whether a network policy selects a pod of the policy namespace from its labels.
+// An empty pod selector selects all the pods of the namespace.
+func NetworkPolicySelects(spec *netv1.NetworkPolicySpec, podLabels map[string]string) bool {
+	return selectorMatches(&spec.PodSelector, podLabels)
+}
+
+// IngressReachable returns whether a port of a pod is reachable from any container of the cluster, given the ingress
+// network policies of the pod namespace and the pods of the cluster. A pod is reachable if no ingress policy selects it
+// (i.e it is not isolated), or if a rule of a selecting policy allows traffic to the port from at least one pod of the
+// cluster (or from any address).
+func IngressReachable(policies []netv1.NetworkPolicySpec, namespace string, podLabels map[string]string,
+	port PolicyPort, sources []PolicySource) bool {
+
+	isolated := false
+	for i := range policies {
+		policy := &policies[i]
+		if !NetworkPolicyIngress(policy) || !NetworkPolicySelects(policy, podLabels) {
+			continue
+		}
+
+		isolated = true
+		for _, rule := range policy.Ingress {
+			if ingressRuleAllows(rule, namespace, port, sources) {
+				return true
+			}
+		}
+	}
+
+	return !isolated
+}
+
+// ingressRuleAllows returns whether an ingress rule of a policy in the provided namespace allows traffic to a port from
+// any of the sources.
+func ingressRuleAllows(rule netv1.NetworkPolicyIngressRule, namespace string, port PolicyPort,
+	sources []PolicySource) bool {
+
+	if !policyPortsMatch(rule.Ports, port) {
+		return false
+	}
+
+	// An empty list of peers allows all sources
+	if len(rule.From) == 0 {
+		return true
+	}
+
+	for _, peer := range rule.From {
+		if anyAddressBlock(peer.IPBlock) {
+			return true
+		}
+
+		for i := range sources {
+			if PolicyPeerMatches(peer, namespace, &sources[i]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// PolicyPeerMatches returns whether a peer of a network policy in the provided namespace matches a source pod. A peer
+// without a namespace selector is restricted to the policy namespace, while a peer without a pod selector matches all
+// the pods of the selected namespaces. IP blocks are matched against the pod IP.
+func PolicyPeerMatches(peer netv1.NetworkPolicyPeer, namespace string, source *PolicySource) bool {
+	if peer.IPBlock != nil {
+		return ipBlockContains(peer.IPBlock, source.PodIP)
+	}
+
+	if peer.NamespaceSelector == nil {
+		if source.Namespace != namespace {
+			return false
+		}
+	} else if !selectorMatches(peer.NamespaceSelector, source.NamespaceLabels) {
+		return false
+	}
+
+	return peer.PodSelector == nil || selectorMatches(peer.PodSelector, source.PodLabels)
+}
+
+// anyAddressBlock returns whether an IP block matches any address.
+func anyAddressBlock(block *netv1.IPBlock) bool {
+	if block == nil || len(block.Except) != 0 {
+		return false
+	}
+
+	for _, cidr := range anyAddressBlocks {
+		if block.CIDR == cidr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ipBlockContains returns whether an IP address belongs to an IP block and none of its exceptions.
+func ipBlockContains(block *netv1.IPBlock, address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil || !cidrContains(block.CIDR, ip) {
+		return false
+	}
+
+	for _, except := range block.Except {
+		if cidrContains(except, ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cidrContains returns whether an IP address belongs to a CIDR block.
+func cidrContains(cidr string, ip net.IP) bool {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		// Invalid blocks are rejected by the API server, so should never be encountered here
+		return false
+	}
+
+	return network.Contains(ip)
+}
+
+// policyPortsMatch returns whether a port matches the list of ports of a network policy rule. An empty list of ports
+// matches all ports.
+func policyPortsMatch(ports []netv1.NetworkPolicyPort, port PolicyPort) bool {
+	if len(ports) == 0 {
+		return true
+	}
+
+	for _, p := range ports {
+		protocol := string(corev1.ProtocolTCP)
+		if p.Protocol != nil {
+			protocol = string(*p.Protocol)
+		}
+
+		if protocol != port.Protocol {
+			continue
+		}
+
+		switch {
+		case p.Port == nil:
+			return true
+		case p.Port.Type == intstr.String:
+			if port.Name != "" && p.Port.StrVal == port.Name {
+				return true
+			}
+		case p.EndPort != nil:
+			if port.Port >= p.Port.IntVal && port.Port <= *p.EndPort {
+				return true
+			}
+		default:
+			if port.Port == p.Port.IntVal {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// selectorMatches returns whether a label selector matches an object from its labels.
+func selectorMatches(selector *metav1.LabelSelector, objectLabels map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		// Invalid selectors are rejected by the API server, so should never be encountered here
+		return false
+	}
+
+	return s.Matches(labels.Set(objectLabels))
+}
@@ -0,0 +1,230 @@
+package libkube
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNetworkPolicyIngress(t *testing.T) {
+	tests := []struct {
+		name  string
+		types []netv1.PolicyType
+		want  bool
+	}{
+		{name: "default", types: nil, want: true},
+		{name: "ingress", types: []netv1.PolicyType{netv1.PolicyTypeIngress}, want: true},
+		{name: "egress", types: []netv1.PolicyType{netv1.PolicyTypeEgress}, want: false},
+		{name: "both", types: []netv1.PolicyType{netv1.PolicyTypeEgress, netv1.PolicyTypeIngress}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &netv1.NetworkPolicySpec{PolicyTypes: tt.types}
+			if got := NetworkPolicyIngress(spec); got != tt.want {
+				t.Errorf("NetworkPolicyIngress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIngressReachable(t *testing.T) {
+	udp := corev1.ProtocolUDP
+	port := func(p intstr.IntOrString) *intstr.IntOrString { return &p }
+	endPort := int32(9000)
+
+	podLabels := map[string]string{"app": "web"}
+	httpPort := PolicyPort{Port: 8080, Name: "http", Protocol: "TCP"}
+	sources := []PolicySource{
+		{
+			Namespace:       "web",
+			NamespaceLabels: map[string]string{"kubernetes.io/metadata.name": "web"},
+			PodLabels:       podLabels,
+			PodIP:           "10.0.0.5",
+		},
+		{
+			Namespace:       "client",
+			NamespaceLabels: map[string]string{"kubernetes.io/metadata.name": "client", "team": "client"},
+			PodLabels:       map[string]string{"app": "client"},
+			PodIP:           "10.1.0.7",
+		},
+	}
+
+	denyAll := netv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{},
+		PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+	}
+	allowRule := func(rule netv1.NetworkPolicyIngressRule) netv1.NetworkPolicySpec {
+		return netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Ingress:     []netv1.NetworkPolicyIngressRule{rule},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		policies []netv1.NetworkPolicySpec
+		want     bool
+	}{
+		{
+			name:     "no policy",
+			policies: nil,
+			want:     true,
+		},
+		{
+			name:     "deny all",
+			policies: []netv1.NetworkPolicySpec{denyAll},
+			want:     false,
+		},
+		{
+			name: "policy selecting other pods",
+			policies: []netv1.NetworkPolicySpec{{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			}},
+			want: true,
+		},
+		{
+			name: "egress only policy",
+			policies: []netv1.NetworkPolicySpec{{
+				PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeEgress},
+			}},
+			want: true,
+		},
+		{
+			name:     "deny all with allow all rule",
+			policies: []netv1.NetworkPolicySpec{denyAll, allowRule(netv1.NetworkPolicyIngressRule{})},
+			want:     true,
+		},
+		{
+			name: "allow numeric port",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				Ports: []netv1.NetworkPolicyPort{{Port: port(intstr.FromInt(8080))}},
+			})},
+			want: true,
+		},
+		{
+			name: "allow named port",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				Ports: []netv1.NetworkPolicyPort{{Port: port(intstr.FromString("http"))}},
+			})},
+			want: true,
+		},
+		{
+			name: "allow port range",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				Ports: []netv1.NetworkPolicyPort{{Port: port(intstr.FromInt(8000)), EndPort: &endPort}},
+			})},
+			want: true,
+		},
+		{
+			name: "allow other port",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				Ports: []netv1.NetworkPolicyPort{{Port: port(intstr.FromInt(443))}},
+			})},
+			want: false,
+		},
+		{
+			name: "allow other protocol",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				Ports: []netv1.NetworkPolicyPort{{Protocol: &udp}},
+			})},
+			want: false,
+		},
+		{
+			name: "allow pods of the policy namespace",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+			})},
+			want: true,
+		},
+		{
+			name: "allow labelled pods of the policy namespace",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{
+					PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}},
+				}},
+			})},
+			want: false,
+		},
+		{
+			name: "allow labelled namespaces",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "web"}},
+				}},
+			})},
+			want: false,
+		},
+		{
+			name: "allow labelled namespaces with pods",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "client"}},
+				}},
+			})},
+			want: true,
+		},
+		{
+			name: "allow labelled pods of labelled namespaces",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "client"}},
+					PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				}},
+			})},
+			want: false,
+		},
+		{
+			name: "allow all namespaces",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+			})},
+			want: true,
+		},
+		{
+			name: "allow any address",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{IPBlock: &netv1.IPBlock{CIDR: "0.0.0.0/0"}}},
+			})},
+			want: true,
+		},
+		{
+			name: "allow any address with exceptions",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{IPBlock: &netv1.IPBlock{CIDR: "0.0.0.0/0", Except: []string{"10.0.0.0/8"}}}},
+			})},
+			want: false,
+		},
+		{
+			name: "allow address block of a pod",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{IPBlock: &netv1.IPBlock{CIDR: "10.1.0.0/16"}}},
+			})},
+			want: true,
+		},
+		{
+			name: "allow address block with pods excepted",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{IPBlock: &netv1.IPBlock{CIDR: "10.0.0.0/8",
+					Except: []string{"10.0.0.0/16", "10.1.0.0/16"}}}},
+			})},
+			want: false,
+		},
+		{
+			name: "allow address block without pods",
+			policies: []netv1.NetworkPolicySpec{allowRule(netv1.NetworkPolicyIngressRule{
+				From: []netv1.NetworkPolicyPeer{{IPBlock: &netv1.IPBlock{CIDR: "192.168.0.0/16"}}},
+			})},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IngressReachable(tt.policies, "web", podLabels, httpPort, sources); got != tt.want {
+				t.Errorf("IngressReachable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
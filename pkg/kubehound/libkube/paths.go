@@ -4,8 +4,25 @@ import (
 	"fmt"
 )
 
+const (
+	// KubeletPodsPath is the directory on the host node holding the kubelet pod volumes (including service account tokens).
+	KubeletPodsPath = "/var/lib/kubelet/pods"
+)
+
 // ServiceAccountTokenPath returns the full path of a pod's service account token on the host node.
 func ServiceAccountTokenPath(podUid string, volumeName string) string {
-	return fmt.Sprintf("/var/lib/kubelet/pods/%s/volumes/kubernetes.io~projected/%s/token",
-		podUid, volumeName)
+	return fmt.Sprintf("%s/%s/volumes/kubernetes.io~projected/%s/token",
+		KubeletPodsPath, podUid, volumeName)
+}
+
+// SecretVolumePath returns the full path of a pod's secret volume on the host node.
+func SecretVolumePath(podUid string, volumeName string) string {
+	return fmt.Sprintf("%s/%s/volumes/kubernetes.io~secret/%s",
+		KubeletPodsPath, podUid, volumeName)
+}
+
+// EmptyDirPath returns the full path of a pod's emptyDir volume on the host node.
+func EmptyDirPath(podUid string, volumeName string) string {
+	return fmt.Sprintf("%s/%s/volumes/kubernetes.io~empty-dir/%s",
+		KubeletPodsPath, podUid, volumeName)
 }
@@ -0,0 +1,46 @@
+package libkube
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PodSecurityEnforceLabel is the namespace label setting the Pod Security Standards level enforced on its pods.
+	PodSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+
+	// PodSecurityAuditLabel is the namespace label setting the Pod Security Standards level audited on its pods.
+	PodSecurityAuditLabel = "pod-security.kubernetes.io/audit"
+
+	// PodSecurityWarnLabel is the namespace label setting the Pod Security Standards level warned on for its pods.
+	PodSecurityWarnLabel = "pod-security.kubernetes.io/warn"
+)
+
+const (
+	PodSecurityLevelPrivileged = "privileged"
+	PodSecurityLevelBaseline   = "baseline"
+	PodSecurityLevelRestricted = "restricted"
+)
+
+// PodSecurityLevel returns the Pod Security Standards level set by a label of the namespace. A missing label defaults
+// to the privileged level, while an invalid value is evaluated as the restricted level by the admission controller.
+// See reference: https://kubernetes.io/docs/concepts/security/pod-security-admission/
+func PodSecurityLevel(ns *corev1.Namespace, label string) string {
+	level, ok := ns.Labels[label]
+	if !ok {
+		return PodSecurityLevelPrivileged
+	}
+
+	switch level {
+	case PodSecurityLevelPrivileged, PodSecurityLevelBaseline, PodSecurityLevelRestricted:
+		return level
+	default:
+		return PodSecurityLevelRestricted
+	}
+}
+
+// PodSecurityAllowsPrivileged returns whether a Pod Security Standards level allows the creation of privileged pods
+// (privileged containers, host namespaces, host path volumes, etc.). Both the baseline and restricted levels prevent
+// the known privilege escalations from a pod to its node.
+func PodSecurityAllowsPrivileged(level string) bool {
+	return level == "" || level == PodSecurityLevelPrivileged
+}
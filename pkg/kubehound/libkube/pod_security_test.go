@@ -0,0 +1,51 @@
+package libkube
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodSecurityLevel(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		label  string
+		want   string
+	}{
+		{name: "no labels", labels: nil, label: PodSecurityEnforceLabel, want: PodSecurityLevelPrivileged},
+		{name: "enforce baseline", labels: map[string]string{PodSecurityEnforceLabel: "baseline"}, label: PodSecurityEnforceLabel, want: PodSecurityLevelBaseline},
+		{name: "enforce restricted", labels: map[string]string{PodSecurityEnforceLabel: "restricted"}, label: PodSecurityEnforceLabel, want: PodSecurityLevelRestricted},
+		{name: "enforce privileged", labels: map[string]string{PodSecurityEnforceLabel: "privileged"}, label: PodSecurityEnforceLabel, want: PodSecurityLevelPrivileged},
+		{name: "invalid level", labels: map[string]string{PodSecurityEnforceLabel: "strict"}, label: PodSecurityEnforceLabel, want: PodSecurityLevelRestricted},
+		{name: "other label", labels: map[string]string{PodSecurityWarnLabel: "restricted"}, label: PodSecurityEnforceLabel, want: PodSecurityLevelPrivileged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}
+			if got := PodSecurityLevel(ns, tt.label); got != tt.want {
+				t.Errorf("PodSecurityLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodSecurityAllowsPrivileged(t *testing.T) {
+	tests := []struct {
+		level string
+		want  bool
+	}{
+		{level: "", want: true},
+		{level: PodSecurityLevelPrivileged, want: true},
+		{level: PodSecurityLevelBaseline, want: false},
+		{level: PodSecurityLevelRestricted, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			if got := PodSecurityAllowsPrivileged(tt.level); got != tt.want {
+				t.Errorf("PodSecurityAllowsPrivileged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
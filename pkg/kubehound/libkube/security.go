@@ -0,0 +1,65 @@
+package libkube
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AppArmorAnnotationPrefix is the prefix of the pod annotation setting the AppArmor profile of a container.
+	AppArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+
+	// AppArmorUnconfined is the AppArmor profile disabling any confinement.
+	AppArmorUnconfined = "unconfined"
+
+	// SeccompPodAnnotation is the deprecated pod annotation setting the seccomp profile of all containers.
+	SeccompPodAnnotation = "seccomp.security.alpha.kubernetes.io/pod"
+
+	// SeccompContainerAnnotationPrefix is the prefix of the deprecated pod annotation setting the seccomp profile of a container.
+	SeccompContainerAnnotationPrefix = "container.seccomp.security.alpha.kubernetes.io/"
+)
+
+// AppArmorProfile returns the AppArmor profile set for the named container of the pod (e.g runtime/default or
+// unconfined). An empty string is returned if no profile is set, leaving the choice to the container runtime.
+func AppArmorProfile(pod *corev1.Pod, name string) string {
+	return pod.Annotations[AppArmorAnnotationPrefix+name]
+}
+
+// SeccompProfile returns the type of the seccomp profile applied to the container of the pod (Unconfined,
+// RuntimeDefault or Localhost). The container security context takes precedence over the pod security context, which
+// itself takes precedence over the deprecated annotations. An empty string is returned if no profile is set.
+// See reference for details: https://kubernetes.io/docs/tutorials/security/seccomp/
+func SeccompProfile(pod *corev1.Pod, container *corev1.Container) string {
+	if container.SecurityContext != nil && container.SecurityContext.SeccompProfile != nil {
+		return string(container.SecurityContext.SeccompProfile.Type)
+	}
+
+	if pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.SeccompProfile != nil {
+		return string(pod.Spec.SecurityContext.SeccompProfile.Type)
+	}
+
+	if annotation, ok := pod.Annotations[SeccompContainerAnnotationPrefix+container.Name]; ok {
+		return seccompAnnotationType(annotation)
+	}
+
+	if annotation, ok := pod.Annotations[SeccompPodAnnotation]; ok {
+		return seccompAnnotationType(annotation)
+	}
+
+	return ""
+}
+
+// seccompAnnotationType converts a deprecated seccomp annotation value to the equivalent seccomp profile type.
+func seccompAnnotationType(annotation string) string {
+	switch {
+	case annotation == "unconfined":
+		return string(corev1.SeccompProfileTypeUnconfined)
+	case annotation == "runtime/default" || annotation == "docker/default":
+		return string(corev1.SeccompProfileTypeRuntimeDefault)
+	case strings.HasPrefix(annotation, "localhost/"):
+		return string(corev1.SeccompProfileTypeLocalhost)
+	default:
+		return ""
+	}
+}
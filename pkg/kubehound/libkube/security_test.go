@@ -0,0 +1,102 @@
+package libkube
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAppArmorProfile(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				AppArmorAnnotationPrefix + "unconfined": "unconfined",
+				AppArmorAnnotationPrefix + "confined":   "runtime/default",
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "unconfined", want: "unconfined"},
+		{name: "confined", want: "runtime/default"},
+		{name: "unset", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AppArmorProfile(pod, tt.name); got != tt.want {
+				t.Errorf("AppArmorProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeccompProfile(t *testing.T) {
+	profile := func(t corev1.SeccompProfileType) *corev1.SeccompProfile {
+		return &corev1.SeccompProfile{Type: t}
+	}
+
+	tests := []struct {
+		name      string
+		pod       corev1.Pod
+		container corev1.Container
+		want      string
+	}{
+		{
+			name: "unset",
+			want: "",
+		},
+		{
+			name: "container security context",
+			container: corev1.Container{
+				SecurityContext: &corev1.SecurityContext{SeccompProfile: profile(corev1.SeccompProfileTypeUnconfined)},
+			},
+			want: "Unconfined",
+		},
+		{
+			name: "container overrides pod security context",
+			pod: corev1.Pod{Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{SeccompProfile: profile(corev1.SeccompProfileTypeUnconfined)},
+			}},
+			container: corev1.Container{
+				SecurityContext: &corev1.SecurityContext{SeccompProfile: profile(corev1.SeccompProfileTypeRuntimeDefault)},
+			},
+			want: "RuntimeDefault",
+		},
+		{
+			name: "pod security context",
+			pod: corev1.Pod{Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{SeccompProfile: profile(corev1.SeccompProfileTypeLocalhost)},
+			}},
+			want: "Localhost",
+		},
+		{
+			name: "container annotation overrides pod annotation",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				SeccompPodAnnotation:                    "runtime/default",
+				SeccompContainerAnnotationPrefix + "c1": "unconfined",
+			}}},
+			container: corev1.Container{Name: "c1"},
+			want:      "Unconfined",
+		},
+		{
+			name: "pod annotation",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				SeccompPodAnnotation: "docker/default",
+			}}},
+			container: corev1.Container{Name: "c1"},
+			want:      "RuntimeDefault",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SeccompProfile(&tt.pod, &tt.container); got != tt.want {
+				t.Errorf("SeccompProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -592,6 +592,8 @@ func TestConverter_PodChildPipeline(t *testing.T) {
 	assert.Equal(t, storeContainer.Inherited.PodName, storePod.K8.Name)
 	assert.Equal(t, storeContainer.Inherited.NodeName, storePod.K8.Spec.NodeName)
 	assert.Equal(t, storeContainer.Inherited.ServiceAccount, storePod.K8.Spec.ServiceAccountName)
+	assert.Equal(t, shared.ContainerTypeRegular, storeContainer.Type)
+	assert.False(t, storeContainer.Terminated)
 
 	// Store container -> graph container
 	graphContainer, err := NewGraph().Container(storeContainer, storePod)
@@ -602,6 +604,7 @@ func TestConverter_PodChildPipeline(t *testing.T) {
 	assert.Equal(t, graphContainer.Service, "test-service")
 	assert.Equal(t, graphContainer.Team, "test-team")
 	assert.Equal(t, storeContainer.K8.Name, graphContainer.Name)
+	assert.Equal(t, shared.ContainerTypeRegular, graphContainer.Type)
 	assert.Equal(t, storeContainer.K8.Image, graphContainer.Image)
 	assert.Equal(t, storeContainer.K8.Command, graphContainer.Command)
 	assert.Equal(t, storeContainer.K8.Args, graphContainer.Args)
@@ -723,6 +726,441 @@ func TestConverter_EndpointPipeline(t *testing.T) {
 	assert.Equal(t, shared.EndpointExposureExternal, graphEp.Exposure)
 }
 
+func TestConverter_ContainerType(t *testing.T) {
+	t.Parallel()
+
+	terminated := v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0}}
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	pod := &store.Pod{
+		Id: store.ObjectID(),
+		K8: v1.Pod{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "app"}},
+				InitContainers: []v1.Container{
+					{Name: "init-done"},
+					{Name: "init-running"},
+				},
+				EphemeralContainers: []v1.EphemeralContainer{
+					{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger"}},
+				},
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "app", State: terminated},
+				},
+				InitContainerStatuses: []v1.ContainerStatus{
+					{Name: "init-done", State: terminated},
+					{Name: "init-running", State: running},
+				},
+				EphemeralContainerStatuses: []v1.ContainerStatus{
+					{Name: "debugger", State: terminated},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		container      v1.Container
+		wantType       string
+		wantTerminated bool
+	}{
+		{
+			name:           "regular containers are restarted",
+			container:      pod.K8.Spec.Containers[0],
+			wantType:       shared.ContainerTypeRegular,
+			wantTerminated: false,
+		},
+		{
+			name:           "completed init container",
+			container:      pod.K8.Spec.InitContainers[0],
+			wantType:       shared.ContainerTypeInit,
+			wantTerminated: true,
+		},
+		{
+			name:           "running init container",
+			container:      pod.K8.Spec.InitContainers[1],
+			wantType:       shared.ContainerTypeInit,
+			wantTerminated: false,
+		},
+		{
+			name:           "exited ephemeral container",
+			container:      v1.Container(pod.K8.Spec.EphemeralContainers[0].EphemeralContainerCommon),
+			wantType:       shared.ContainerTypeEphemeral,
+			wantTerminated: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			storeContainer, err := NewStore().Container(context.TODO(), &tt.container, pod)
+			assert.NoError(t, err, "store container convert error")
+			assert.Equal(t, tt.wantType, storeContainer.Type)
+			assert.Equal(t, tt.wantTerminated, storeContainer.Terminated)
+
+			graphContainer, err := NewGraph().Container(storeContainer, pod)
+			assert.NoError(t, err, "graph container convert error")
+			assert.Equal(t, tt.wantType, graphContainer.Type)
+		})
+	}
+}
+
+func TestConverter_PersistentVolume(t *testing.T) {
+	t.Parallel()
+
+	local := &v1.PersistentVolume{
+		Spec: v1.PersistentVolumeSpec{
+			StorageClassName: "local-storage",
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				Local: &v1.LocalVolumeSource{Path: "/mnt/disks/ssd1"},
+			},
+		},
+	}
+	local.Name = "local-pv"
+
+	pv, err := NewStore().PersistentVolume(context.TODO(), local)
+	assert.NoError(t, err, "store persistent volume convert error")
+	assert.Equal(t, "local-pv", pv.Name)
+	assert.Equal(t, shared.VolumeTypeHost, pv.Type)
+	assert.Equal(t, "/mnt/disks/ssd1", pv.SourcePath)
+	assert.Equal(t, "local-storage", pv.StorageClass)
+
+	nfs := &v1.PersistentVolume{
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				NFS: &v1.NFSVolumeSource{Server: "nfs.local", Path: "/exports"},
+			},
+		},
+	}
+
+	_, err = NewStore().PersistentVolume(context.TODO(), nfs)
+	assert.ErrorIs(t, err, ErrUnsupportedVolume)
+}
+
+func TestConverter_VolumePersistentVolumeClaim(t *testing.T) {
+	t.Parallel()
+
+	pod := &store.Pod{
+		Id:     store.ObjectID(),
+		NodeId: store.ObjectID(),
+		K8: v1.Pod{
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{
+						Name: "data",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data-app-0", ReadOnly: true},
+						},
+					},
+					{
+						Name: "unbound",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "unbound-claim"},
+						},
+					},
+				},
+			},
+		},
+	}
+	pod.K8.Namespace = "test-app"
+	container := &store.Container{Id: store.ObjectID()}
+
+	c := mocks.NewCacheReader(t)
+	c.EXPECT().Get(mock.Anything, cachekey.PersistentVolumeClaim("data-app-0", "test-app")).Return(&cache.CacheResult{
+		Value: "local-pv",
+		Err:   nil,
+	})
+	c.EXPECT().Get(mock.Anything, cachekey.PersistentVolume("local-pv")).Return(&cache.CacheResult{
+		Value: store.PersistentVolume{
+			Name:         "local-pv",
+			Type:         shared.VolumeTypeHost,
+			SourcePath:   "/mnt/disks/ssd1",
+			StorageClass: "local-storage",
+		},
+		Err: nil,
+	})
+	c.EXPECT().Get(mock.Anything, cachekey.PersistentVolumeClaim("unbound-claim", "test-app")).Return(&cache.CacheResult{
+		Value: nil,
+		Err:   cache.ErrNoEntry,
+	})
+
+	// Claim bound to a persistent volume backed by the host
+	mount := &v1.VolumeMount{Name: "data", MountPath: "/data"}
+	storeVolume, err := NewStoreWithCache(c).Volume(context.TODO(), mount, pod, container)
+	assert.NoError(t, err, "store volume convert error")
+	assert.Equal(t, shared.VolumeTypeHost, storeVolume.Type)
+	assert.Equal(t, "/mnt/disks/ssd1", storeVolume.SourcePath)
+	assert.Equal(t, "local-storage", storeVolume.StorageClass)
+	assert.Equal(t, pod.NodeId, storeVolume.NodeId)
+	assert.True(t, storeVolume.ReadOnly)
+
+	// Claim not bound (or bound to an unsupported persistent volume)
+	mount = &v1.VolumeMount{Name: "unbound", MountPath: "/unbound"}
+	_, err = NewStoreWithCache(c).Volume(context.TODO(), mount, pod, container)
+	assert.ErrorIs(t, err, ErrUnboundClaim)
+}
+
+func TestConverter_VolumeEmptyDir(t *testing.T) {
+	t.Parallel()
+
+	pod := &store.Pod{
+		Id:     store.ObjectID(),
+		NodeId: store.ObjectID(),
+		K8: v1.Pod{
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{
+						Name: "shared-data",
+						VolumeSource: v1.VolumeSource{
+							EmptyDir: &v1.EmptyDirVolumeSource{},
+						},
+					},
+				},
+			},
+		},
+	}
+	pod.K8.UID = "5a9fc508-8410-444a-bf63-9f11e5979da3"
+	container := &store.Container{Id: store.ObjectID()}
+
+	mount := &v1.VolumeMount{Name: "shared-data", MountPath: "/data"}
+	storeVolume, err := NewStoreWithCache(mocks.NewCacheReader(t)).Volume(context.TODO(), mount, pod, container)
+	assert.NoError(t, err, "store volume convert error")
+	assert.Equal(t, shared.VolumeTypeEmptyDir, storeVolume.Type)
+	assert.Equal(t, "/var/lib/kubelet/pods/5a9fc508-8410-444a-bf63-9f11e5979da3/volumes/kubernetes.io~empty-dir/shared-data",
+		storeVolume.SourcePath)
+	assert.Equal(t, pod.Id, storeVolume.PodId)
+	assert.Equal(t, pod.NodeId, storeVolume.NodeId)
+	assert.False(t, storeVolume.ReadOnly)
+}
+
+func TestConverter_VolumeSecret(t *testing.T) {
+	t.Parallel()
+
+	pod := &store.Pod{
+		Id:     store.ObjectID(),
+		NodeId: store.ObjectID(),
+		K8: v1.Pod{
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{
+						Name: "legacy-token",
+						VolumeSource: v1.VolumeSource{
+							Secret: &v1.SecretVolumeSource{SecretName: "app-sa-token"},
+						},
+					},
+				},
+			},
+		},
+	}
+	pod.K8.UID = "5a9fc508-8410-444a-bf63-9f11e5979da3"
+	container := &store.Container{Id: store.ObjectID()}
+
+	mount := &v1.VolumeMount{Name: "legacy-token", MountPath: "/var/run/secrets/app"}
+	storeVolume, err := NewStoreWithCache(mocks.NewCacheReader(t)).Volume(context.TODO(), mount, pod, container)
+	assert.NoError(t, err, "store volume convert error")
+	assert.Equal(t, shared.VolumeTypeSecret, storeVolume.Type)
+	assert.Equal(t, "/var/lib/kubelet/pods/5a9fc508-8410-444a-bf63-9f11e5979da3/volumes/kubernetes.io~secret/legacy-token",
+		storeVolume.SourcePath)
+	assert.Equal(t, container.Id, storeVolume.ContainerId)
+	assert.True(t, storeVolume.ReadOnly)
+}
+
+func TestConverter_ContainerSecrets(t *testing.T) {
+	t.Parallel()
+
+	pod := &store.Pod{
+		Id: store.ObjectID(),
+		K8: v1.Pod{
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{
+						Name: "legacy-token",
+						VolumeSource: v1.VolumeSource{
+							Secret: &v1.SecretVolumeSource{SecretName: "volume-secret"},
+						},
+					},
+					{
+						Name: "bundle",
+						VolumeSource: v1.VolumeSource{
+							Projected: &v1.ProjectedVolumeSource{
+								Sources: []v1.VolumeProjection{
+									{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: "projected-secret"}}},
+								},
+							},
+						},
+					},
+					{
+						Name: "unmounted",
+						VolumeSource: v1.VolumeSource{
+							Secret: &v1.SecretVolumeSource{SecretName: "unmounted-secret"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	input := &v1.Container{
+		Name: "app",
+		Env: []v1.EnvVar{
+			{Name: "PLAIN", Value: "value"},
+			{Name: "TOKEN", ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "env-secret"}, Key: "token"},
+			}},
+		},
+		EnvFrom: []v1.EnvFromSource{
+			{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "envfrom-secret"}}},
+			{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "env-secret"}}},
+		},
+		VolumeMounts: []v1.VolumeMount{
+			{Name: "legacy-token", MountPath: "/var/run/secrets/app"},
+			{Name: "bundle", MountPath: "/etc/bundle"},
+		},
+	}
+
+	storeContainer, err := NewStore().Container(context.TODO(), input, pod)
+	assert.NoError(t, err, "store container convert error")
+	assert.Equal(t, []string{"env-secret", "envfrom-secret", "projected-secret", "volume-secret"}, storeContainer.Secrets)
+}
+
+func TestConverter_ServiceAccountPipeline(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	input := &v1.ServiceAccount{}
+	input.Name = "app-sa"
+	input.Namespace = "test-app"
+	input.Labels = map[string]string{"team": "test-team"}
+	input.Annotations = map[string]string{
+		"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/app-role",
+	}
+
+	// Collector input -> store model
+	storeId, err := NewStore().ServiceAccountIdentity(ctx, input)
+	assert.NoError(t, err, "store service account identity convert error")
+	assert.Equal(t, "app-sa", storeId.Name)
+	assert.Equal(t, "test-app", storeId.Namespace)
+	assert.True(t, storeId.IsNamespaced)
+	assert.Equal(t, "ServiceAccount", storeId.Type)
+
+	storeCloudIds, err := NewStore().WorkloadCloudIdentities(ctx, input)
+	assert.NoError(t, err, "store workload cloud identities convert error")
+	assert.Len(t, storeCloudIds, 1)
+
+	storeCloudId := storeCloudIds[0]
+	assert.Equal(t, "arn:aws:iam::123456789012:role/app-role", storeCloudId.Name)
+	assert.Equal(t, shared.CloudProviderAWS, storeCloudId.Provider)
+	assert.Equal(t, shared.CloudIdentityTypeWorkload, storeCloudId.Type)
+	assert.Equal(t, "test-app", storeCloudId.Namespace)
+	assert.Equal(t, "app-sa", storeCloudId.ServiceAccount)
+
+	// Store model -> graph model
+	graphCloudId, err := NewGraph().CloudIdentity(storeCloudId)
+	assert.NoError(t, err, "graph cloud identity convert error")
+
+	assert.Equal(t, storeCloudId.Id.Hex(), graphCloudId.StoreID)
+	assert.Equal(t, "test-team", graphCloudId.Team)
+	assert.Equal(t, storeCloudId.Name, graphCloudId.Name)
+	assert.Equal(t, storeCloudId.Provider, graphCloudId.Provider)
+	assert.Equal(t, storeCloudId.Type, graphCloudId.Type)
+	assert.Empty(t, graphCloudId.Namespace)
+	assert.False(t, graphCloudId.IsNamespaced)
+}
+
+func TestConverter_InstanceCloudIdentity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	node := &store.Node{
+		Id: store.ObjectID(),
+		K8: v1.Node{
+			Spec: v1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0123456789abcdef0"},
+		},
+	}
+
+	storeCloudId, err := NewStore().InstanceCloudIdentity(ctx, node)
+	assert.NoError(t, err, "store instance cloud identity convert error")
+	assert.Equal(t, "i-0123456789abcdef0", storeCloudId.Name)
+	assert.Equal(t, shared.CloudProviderAWS, storeCloudId.Provider)
+	assert.Equal(t, shared.CloudIdentityTypeInstance, storeCloudId.Type)
+	assert.Equal(t, node.Id, storeCloudId.NodeId)
+	assert.False(t, storeCloudId.IsNamespaced)
+
+	// Nodes not running on a cloud provider instance
+	node.K8.Spec.ProviderID = "kind://docker/kind/kind-worker"
+	_, err = NewStore().InstanceCloudIdentity(ctx, node)
+	assert.ErrorIs(t, err, ErrNoCloudInstance)
+}
+
+func TestConverter_SecretPipeline(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	input := &v1.Secret{}
+	input.Name = "app-sa-token"
+	input.Namespace = "test-app"
+	input.Type = v1.SecretTypeServiceAccountToken
+	input.Labels = map[string]string{"team": "test-team"}
+	input.Annotations = map[string]string{"kubernetes.io/service-account.name": "app-sa"}
+
+	// Collector input -> store model
+	storeSecret, err := NewStore().Secret(ctx, input)
+	assert.NoError(t, err, "store secret convert error")
+	assert.Equal(t, "app-sa-token", storeSecret.Name)
+	assert.Equal(t, "test-app", storeSecret.Namespace)
+	assert.True(t, storeSecret.IsNamespaced)
+	assert.Equal(t, "kubernetes.io/service-account-token", storeSecret.Type)
+	assert.Equal(t, "app-sa", storeSecret.ServiceAccount)
+
+	// Store model -> graph model
+	graphSecret, err := NewGraph().Secret(storeSecret)
+	assert.NoError(t, err, "graph secret convert error")
+
+	assert.Equal(t, storeSecret.Id.Hex(), graphSecret.StoreID)
+	assert.Equal(t, "test-team", graphSecret.Team)
+	assert.Equal(t, "app-sa-token", graphSecret.Name)
+	assert.Equal(t, "test-app", graphSecret.Namespace)
+	assert.True(t, graphSecret.IsNamespaced)
+	assert.Equal(t, "kubernetes.io/service-account-token", graphSecret.Type)
+	assert.Equal(t, "app-sa", graphSecret.ServiceAccount)
+}
+
+func TestConverter_NamespacePipeline(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	input := &v1.Namespace{}
+	input.Name = "test-app"
+	input.Labels = map[string]string{
+		"team":                               "test-team",
+		"pod-security.kubernetes.io/enforce": "baseline",
+		"pod-security.kubernetes.io/warn":    "restricted",
+	}
+
+	// Collector input -> store model
+	storeNs, err := NewStore().Namespace(ctx, input)
+	assert.NoError(t, err, "store namespace convert error")
+	assert.Equal(t, "test-app", storeNs.Name)
+	assert.Equal(t, input.Labels, storeNs.Labels)
+	assert.Equal(t, "baseline", storeNs.PSAEnforce)
+	assert.Equal(t, "privileged", storeNs.PSAAudit)
+	assert.Equal(t, "restricted", storeNs.PSAWarn)
+
+	// Store model -> graph model
+	graphNs, err := NewGraph().Namespace(storeNs)
+	assert.NoError(t, err, "graph namespace convert error")
+
+	assert.Equal(t, storeNs.Id.Hex(), graphNs.StoreID)
+	assert.Equal(t, "test-team", graphNs.Team)
+	assert.Equal(t, "test-app", graphNs.Name)
+	assert.Equal(t, "baseline", graphNs.PSAEnforce)
+	assert.Equal(t, "privileged", graphNs.PSAAudit)
+	assert.Equal(t, "restricted", graphNs.PSAWarn)
+	assert.False(t, graphNs.IsNamespaced)
+}
+
 func TestConverter_EndpointPrivatePipeline(t *testing.T) {
 	t.Parallel()
 
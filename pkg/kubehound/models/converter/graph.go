@@ -29,6 +29,7 @@ func (c *GraphConverter) Container(input *store.Container, parent *store.Pod) (*
 		Service:     input.Ownership.Service,
 		Namespace:   input.Inherited.Namespace,
 		Name:        input.K8.Name,
+		Type:        input.Type,
 		Image:       input.K8.Image,
 		Command:     input.K8.Command,
 		Args:        input.K8.Args,
@@ -215,6 +216,55 @@ func (c *GraphConverter) Identity(input *store.Identity) (*graph.Identity, error
 	return output, nil
 }
 
+// CloudIdentity returns the graph representation of a cloud identity vertex from a store cloud identity model input.
+// The vertex is shared by all the bindings of the cloud identity, so it is not namespaced.
+func (c *GraphConverter) CloudIdentity(input *store.CloudIdentity) (*graph.CloudIdentity, error) {
+	output := &graph.CloudIdentity{
+		StoreID:  input.Id.Hex(),
+		App:      input.Ownership.Application,
+		Team:     input.Ownership.Team,
+		Service:  input.Ownership.Service,
+		Name:     input.Name,
+		Provider: input.Provider,
+		Type:     input.Type,
+	}
+
+	return output, nil
+}
+
+// Secret returns the graph representation of a secret vertex from a store secret model input.
+func (c *GraphConverter) Secret(input *store.Secret) (*graph.Secret, error) {
+	output := &graph.Secret{
+		StoreID:        input.Id.Hex(),
+		App:            input.Ownership.Application,
+		Team:           input.Ownership.Team,
+		Service:        input.Ownership.Service,
+		IsNamespaced:   input.IsNamespaced,
+		Namespace:      input.Namespace,
+		Name:           input.Name,
+		Type:           input.Type,
+		ServiceAccount: input.ServiceAccount,
+	}
+
+	return output, nil
+}
+
+// Namespace returns the graph representation of a namespace vertex from a store namespace model input.
+func (c *GraphConverter) Namespace(input *store.Namespace) (*graph.Namespace, error) {
+	output := &graph.Namespace{
+		StoreID:    input.Id.Hex(),
+		App:        input.Ownership.Application,
+		Team:       input.Ownership.Team,
+		Service:    input.Ownership.Service,
+		Name:       input.Name,
+		PSAEnforce: input.PSAEnforce,
+		PSAAudit:   input.PSAAudit,
+		PSAWarn:    input.PSAWarn,
+	}
+
+	return output, nil
+}
+
 // Endpoint returns the graph representation of an endpoint vertex from a store endpoint model input.
 func (c *GraphConverter) Endpoint(input *store.Endpoint) (*graph.Endpoint, error) {
 	output := &graph.Endpoint{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +32,8 @@ var (
 	ErrEndpointTarget        = errors.New("target reference for an endpoint could not be resolved")
 	ErrRoleCacheMiss         = errors.New("missing role in cache")
 	ErrRoleBindProperties    = errors.New("incorrect combination of (cluster) role and (cluster) role binding properties")
+	ErrUnboundClaim          = errors.New("persistent volume claim not bound to a supported persistent volume")
+	ErrNoCloudInstance       = errors.New("node not running on a supported cloud provider instance")
 )
 
 // StoreConverter enables converting between an input K8s model to its equivalent store model.
@@ -69,6 +72,9 @@ func (c *StoreConverter) Container(_ context.Context, input types.ContainerType,
 		Ownership: store.ExtractOwnership(parent.K8.Labels),
 	}
 
+	// Init and ephemeral containers are only identified by their name within the parent pod
+	output.Type, output.Terminated = containerRuntime(&parent.K8, input.Name)
+
 	// Certain fields are set by the PodSecurityContext and overriden by the container's SecurityContext.
 	// Currently we only consider the RunAsUser field.
 	if input.SecurityContext != nil && input.SecurityContext.RunAsUser != nil {
@@ -77,9 +83,95 @@ func (c *StoreConverter) Container(_ context.Context, input types.ContainerType,
 		output.Inherited.RunAsUser = *parent.K8.Spec.SecurityContext.RunAsUser
 	}
 
+	// Confinement profiles can be set via the pod annotations as well as the security contexts
+	output.Inherited.AppArmor = libkube.AppArmorProfile(&parent.K8, input.Name)
+	output.Inherited.Seccomp = libkube.SeccompProfile(&parent.K8, &output.K8)
+
+	// Secrets referenced by the container are resolved against the store secrets when building edges
+	output.Secrets = containerSecrets(&parent.K8, &output.K8)
+
 	return output, nil
 }
 
+// containerSecrets returns the names of the secrets (within the pod namespace) exposed to the container, either via
+// environment variables (secretKeyRef/envFrom) or via mounted secret and projected volumes.
+func containerSecrets(pod *corev1.Pod, container *corev1.Container) []string {
+	names := make(map[string]struct{})
+
+	for _, env := range container.Env {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			names[env.ValueFrom.SecretKeyRef.Name] = struct{}{}
+		}
+	}
+
+	for _, env := range container.EnvFrom {
+		if env.SecretRef != nil {
+			names[env.SecretRef.Name] = struct{}{}
+		}
+	}
+
+	// Expect a small size array so iterating through this is quicker than building up a map for lookup
+	for _, mount := range container.VolumeMounts {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.Name != mount.Name {
+				continue
+			}
+
+			switch {
+			case volume.Secret != nil:
+				names[volume.Secret.SecretName] = struct{}{}
+			case volume.Projected != nil:
+				for _, proj := range volume.Projected.Sources {
+					if proj.Secret != nil {
+						names[proj.Secret.Name] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	secrets := make([]string, 0, len(names))
+	for name := range names {
+		if len(name) != 0 {
+			secrets = append(secrets, name)
+		}
+	}
+	sort.Strings(secrets)
+
+	return secrets
+}
+
+// containerRuntime returns the type of the named container within the pod and whether it has terminated for good.
+// Container names are unique across all the container lists of a pod. Regular containers are restarted as per the pod
+// restart policy and are never considered terminated. Completed init containers and exited ephemeral containers are
+// never restarted within the pod lifetime and thus are considered terminated.
+func containerRuntime(pod *corev1.Pod, name string) (string, bool) {
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == name {
+			return shared.ContainerTypeInit, containerTerminated(pod.Status.InitContainerStatuses, name)
+		}
+	}
+
+	for _, c := range pod.Spec.EphemeralContainers {
+		if c.Name == name {
+			return shared.ContainerTypeEphemeral, containerTerminated(pod.Status.EphemeralContainerStatuses, name)
+		}
+	}
+
+	return shared.ContainerTypeRegular, false
+}
+
+// containerTerminated returns whether the status of the named container reports a terminated state.
+func containerTerminated(statuses []corev1.ContainerStatus, name string) bool {
+	for _, s := range statuses {
+		if s.Name == name {
+			return s.State.Terminated != nil
+		}
+	}
+
+	return false
+}
+
 // Node returns the store representation of a K8s node from an input K8s node object.
 func (c *StoreConverter) Node(ctx context.Context, input types.NodeType) (*store.Node, error) {
 	if c.cache == nil {
@@ -165,8 +257,35 @@ func (c *StoreConverter) handleProjectedToken(ctx context.Context, input types.V
 	return said, sourcePath, nil
 }
 
+// handlePersistentVolumeClaim resolves a persistent volume claim to the underlying persistent volume.
+func (c *StoreConverter) handlePersistentVolumeClaim(ctx context.Context, volume *corev1.Volume,
+	pod *store.Pod) (*store.PersistentVolume, error) {
+
+	// Retrieve the persistent volume bound to the claim from the cache
+	pvName, err := c.cache.Get(ctx, cachekey.PersistentVolumeClaim(volume.PersistentVolumeClaim.ClaimName, pod.K8.Namespace)).Text()
+	switch err {
+	case nil:
+		// Claim is bound to a persistent volume, continue to retrieve the volume
+	case cache.ErrNoEntry:
+		return nil, ErrUnboundClaim
+	default:
+		return nil, err
+	}
+
+	// Only persistent volumes backed by a supported volume type are cached
+	pv, err := c.cache.Get(ctx, cachekey.PersistentVolume(pvName)).PersistentVolume()
+	switch err {
+	case nil:
+		return pv, nil
+	case cache.ErrNoEntry:
+		return nil, ErrUnboundClaim
+	default:
+		return nil, err
+	}
+}
+
 // Volume returns the store representation of a K8s mounted volume from an input K8s volume object.
-// NOTE: requires cache access (IdentityKey).
+// NOTE: requires cache access (IdentityKey, PersistentVolumeClaimKey, PersistentVolumeKey).
 func (c *StoreConverter) Volume(ctx context.Context, input types.VolumeMountType, pod *store.Pod,
 	container *store.Container) (*store.Volume, error) {
 
@@ -207,6 +326,27 @@ func (c *StoreConverter) Volume(ctx context.Context, input types.VolumeMountType
 				output.Type = shared.VolumeTypeProjected
 				output.SourcePath = source
 				output.ProjectedId = said
+			case volume.PersistentVolumeClaim != nil:
+				pv, err := c.handlePersistentVolumeClaim(ctx, &volume, pod)
+				if err != nil {
+					return nil, fmt.Errorf("persistent volume claim volume (%s) processing: %w", volume.Name, err)
+				}
+
+				output.Type = pv.Type
+				output.SourcePath = pv.SourcePath
+				output.StorageClass = pv.StorageClass
+				output.ReadOnly = input.ReadOnly || volume.PersistentVolumeClaim.ReadOnly
+			case volume.EmptyDir != nil:
+				// Memory backed emptyDir volumes are not written to the node disk, but are still shared by all the
+				// containers of the pod in the same way
+				output.Type = shared.VolumeTypeEmptyDir
+				output.SourcePath = libkube.EmptyDirPath(string(pod.K8.ObjectMeta.UID), volume.Name)
+			case volume.Secret != nil:
+				// Secret volumes are always mounted read-only by the kubelet, the secret values are never collected.
+				// The exposed secret is tracked on the container (see containerSecrets).
+				output.Type = shared.VolumeTypeSecret
+				output.SourcePath = libkube.SecretVolumePath(string(pod.K8.ObjectMeta.UID), volume.Name)
+				output.ReadOnly = true
 			default:
 				return nil, ErrUnsupportedVolume
 			}
@@ -222,6 +362,27 @@ func (c *StoreConverter) Volume(ctx context.Context, input types.VolumeMountType
 	return output, nil
 }
 
+// PersistentVolume returns the store representation of a K8s persistent volume from an input K8s PersistentVolume
+// object. Only persistent volumes backed by a directory of the host node (hostPath and local) are supported.
+func (c *StoreConverter) PersistentVolume(_ context.Context, input types.PersistentVolumeType) (*store.PersistentVolume, error) {
+	output := &store.PersistentVolume{
+		Name:         input.Name,
+		Type:         shared.VolumeTypeHost,
+		StorageClass: input.Spec.StorageClassName,
+	}
+
+	switch {
+	case input.Spec.HostPath != nil:
+		output.SourcePath = input.Spec.HostPath.Path
+	case input.Spec.Local != nil:
+		output.SourcePath = input.Spec.Local.Path
+	default:
+		return nil, ErrUnsupportedVolume
+	}
+
+	return output, nil
+}
+
 // Role returns the store representation of a K8s role from an input K8s Role object.
 func (c *StoreConverter) Role(_ context.Context, input types.RoleType) (*store.Role, error) {
 	return &store.Role{
@@ -368,6 +529,112 @@ func (c *StoreConverter) Identity(_ context.Context, input *store.BindSubject, p
 	return output, nil
 }
 
+// ServiceAccountIdentity returns the store representation of a K8s service account identity from an input K8s
+// ServiceAccount object. This is only required for service accounts not referenced by any role binding subject.
+func (c *StoreConverter) ServiceAccountIdentity(_ context.Context, input types.ServiceAccountType) (*store.Identity, error) {
+	output := &store.Identity{
+		Id:           store.ObjectID(),
+		Name:         input.Name,
+		IsNamespaced: true,
+		Namespace:    input.Namespace,
+		Type:         rbacv1.ServiceAccountKind,
+		Ownership:    store.ExtractOwnership(input.Labels),
+	}
+
+	return output, nil
+}
+
+// WorkloadCloudIdentities returns the store representation of the cloud identities bound to an input K8s
+// ServiceAccount object via the workload identity annotations of the supported cloud providers.
+func (c *StoreConverter) WorkloadCloudIdentities(_ context.Context, input types.ServiceAccountType) ([]*store.CloudIdentity, error) {
+	refs := libkube.WorkloadCloudIdentities(input)
+	output := make([]*store.CloudIdentity, 0, len(refs))
+	for _, ref := range refs {
+		id := store.ObjectID()
+		output = append(output, &store.CloudIdentity{
+			Id:             id,
+			CanonicalId:    id,
+			Name:           ref.Name,
+			Provider:       ref.Provider,
+			Type:           shared.CloudIdentityTypeWorkload,
+			IsNamespaced:   true,
+			Namespace:      input.Namespace,
+			ServiceAccount: input.Name,
+			Ownership:      store.ExtractOwnership(input.Labels),
+		})
+	}
+
+	return output, nil
+}
+
+// InstanceCloudIdentity returns the store representation of the cloud identity attached to the cloud instance running
+// an input store node. Nodes not running on a supported cloud provider instance return ErrNoCloudInstance.
+func (c *StoreConverter) InstanceCloudIdentity(_ context.Context, node *store.Node) (*store.CloudIdentity, error) {
+	ref, ok := libkube.NodeCloudInstance(&node.K8)
+	if !ok {
+		return nil, ErrNoCloudInstance
+	}
+
+	id := store.ObjectID()
+	output := &store.CloudIdentity{
+		Id:          id,
+		CanonicalId: id,
+		Name:        ref.Name,
+		Provider:    ref.Provider,
+		Type:        shared.CloudIdentityTypeInstance,
+		NodeId:      node.Id,
+		Ownership:   node.Ownership,
+	}
+
+	return output, nil
+}
+
+// NetworkPolicy returns the store representation of a K8s network policy from an input K8s NetworkPolicy object.
+func (c *StoreConverter) NetworkPolicy(_ context.Context, input types.NetworkPolicyType) (*store.NetworkPolicy, error) {
+	output := &store.NetworkPolicy{
+		Id:           store.ObjectID(),
+		Name:         input.Name,
+		IsNamespaced: true,
+		Namespace:    input.Namespace,
+		K8:           input.Spec,
+		Ownership:    store.ExtractOwnership(input.ObjectMeta.Labels),
+	}
+
+	return output, nil
+}
+
+// Secret returns the store representation of a K8s secret from an input K8s Secret object. Only the metadata of the
+// secret is used, the values are never read.
+func (c *StoreConverter) Secret(_ context.Context, input types.SecretType) (*store.Secret, error) {
+	output := &store.Secret{
+		Id:             store.ObjectID(),
+		Name:           input.Name,
+		IsNamespaced:   true,
+		Namespace:      input.Namespace,
+		Type:           string(input.Type),
+		ServiceAccount: input.Annotations[corev1.ServiceAccountNameKey],
+		Ownership:      store.ExtractOwnership(input.ObjectMeta.Labels),
+	}
+
+	return output, nil
+}
+
+// Namespace returns the store representation of a K8s namespace from an input K8s Namespace object.
+func (c *StoreConverter) Namespace(_ context.Context, input types.NamespaceType) (*store.Namespace, error) {
+	ns := (*corev1.Namespace)(input)
+	output := &store.Namespace{
+		Id:         store.ObjectID(),
+		Name:       ns.Name,
+		Labels:     ns.Labels,
+		PSAEnforce: libkube.PodSecurityLevel(ns, libkube.PodSecurityEnforceLabel),
+		PSAAudit:   libkube.PodSecurityLevel(ns, libkube.PodSecurityAuditLabel),
+		PSAWarn:    libkube.PodSecurityLevel(ns, libkube.PodSecurityWarnLabel),
+		Ownership:  store.ExtractOwnership(ns.ObjectMeta.Labels),
+	}
+
+	return output, nil
+}
+
 // PermissionSet returns the store representation of a K8s role / rolebinding combination from input K8s objects.
 // RBAC rules and limitation:
 //   - Roles and RoleBindings must exist in the same namespace.
@@ -0,0 +1,13 @@
+package graph
+
+type CloudIdentity struct {
+	StoreID      string `json:"storeID" mapstructure:"storeID"`
+	App          string `json:"app" mapstructure:"app"`
+	Team         string `json:"team" mapstructure:"team"`
+	Service      string `json:"service" mapstructure:"service"`
+	IsNamespaced bool   `json:"isNamespaced" mapstructure:"isNamespaced"`
+	Namespace    string `json:"namespace" mapstructure:"namespace"`
+	Name         string `json:"name" mapstructure:"name"`
+	Provider     string `json:"provider" mapstructure:"provider"`
+	Type         string `json:"type" mapstructure:"type"`
+}
@@ -12,6 +12,7 @@ type Container struct {
 	IsNamespaced bool                  `json:"isNamespaced" mapstructure:"isNamespaced"`
 	Namespace    string                `json:"namespace" mapstructure:"namespace"`
 	Name         string                `json:"name" mapstructure:"name"`
+	Type         string                `json:"type" mapstructure:"type"`
 	Image        string                `json:"image" mapstructure:"image"`
 	Command      []string              `json:"command" mapstructure:"command"`
 	Args         []string              `json:"args" mapstructure:"args"`
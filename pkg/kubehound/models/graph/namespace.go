@@ -0,0 +1,14 @@
+package graph
+
+type Namespace struct {
+	StoreID      string `json:"storeID" mapstructure:"storeID"`
+	App          string `json:"app" mapstructure:"app"`
+	Team         string `json:"team" mapstructure:"team"`
+	Service      string `json:"service" mapstructure:"service"`
+	IsNamespaced bool   `json:"isNamespaced" mapstructure:"isNamespaced"`
+	Namespace    string `json:"namespace" mapstructure:"namespace"`
+	Name         string `json:"name" mapstructure:"name"`
+	PSAEnforce   string `json:"psaEnforce" mapstructure:"psaEnforce"`
+	PSAAudit     string `json:"psaAudit" mapstructure:"psaAudit"`
+	PSAWarn      string `json:"psaWarn" mapstructure:"psaWarn"`
+}
@@ -3,6 +3,14 @@ package shared
 const (
 	VolumeTypeHost      = "HostPath"
 	VolumeTypeProjected = "Projected"
+	VolumeTypeEmptyDir  = "EmptyDir"
+	VolumeTypeSecret    = "Secret"
+)
+
+const (
+	ContainerTypeRegular   = "Regular"
+	ContainerTypeInit      = "Init"
+	ContainerTypeEphemeral = "Ephemeral"
 )
 
 const (
@@ -11,6 +19,17 @@ const (
 	TokenTypeOIDC     = "OIDC"
 )
 
+const (
+	CloudProviderAWS   = "AWS"
+	CloudProviderGCP   = "GCP"
+	CloudProviderAzure = "Azure"
+)
+
+const (
+	CloudIdentityTypeWorkload = "Workload" // Cloud identity bound to a service account via workload identity federation
+	CloudIdentityTypeInstance = "Instance" // Cloud identity attached to the instance running a node
+)
+
 type CompromiseType int
 
 const (
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CloudIdentity struct {
+	Id             primitive.ObjectID `bson:"_id"`
+	CanonicalId    primitive.ObjectID `bson:"canonical_id"` // First entry of the same provider and name, holding the graph vertex
+	Name           string             `bson:"name"`
+	Provider       string             `bson:"provider"`
+	Type           string             `bson:"type"`
+	IsNamespaced   bool               `bson:"is_namespaced"`
+	Namespace      string             `bson:"namespace"`
+	ServiceAccount string             `bson:"service_account"` // Bound service account, for workload identities
+	NodeId         primitive.ObjectID `bson:"node_id"`         // Node running on the instance, for instance identities
+	Ownership      OwnershipInfo      `bson:"ownership"`
+}
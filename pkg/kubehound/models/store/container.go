@@ -18,13 +18,18 @@ type ContainerInherited struct {
 	HostNetwork    bool   `bson:"host_net"`
 	ServiceAccount string `bson:"service_account"`
 	RunAsUser      int64  `bson:"run_as_user"`
+	AppArmor       string `bson:"apparmor"` // AppArmor profile, empty if left to the container runtime
+	Seccomp        string `bson:"seccomp"`  // Seccomp profile type, empty if left to the container runtime
 }
 
 type Container struct {
-	Id        primitive.ObjectID `bson:"_id"`
-	PodId     primitive.ObjectID `bson:"pod_id"`
-	NodeId    primitive.ObjectID `bson:"node_id"`
-	Inherited ContainerInherited `bson:"inherited"`
-	K8        corev1.Container   `bson:"k8"`
-	Ownership OwnershipInfo      `bson:"ownership"`
+	Id         primitive.ObjectID `bson:"_id"`
+	PodId      primitive.ObjectID `bson:"pod_id"`
+	NodeId     primitive.ObjectID `bson:"node_id"`
+	Type       string             `bson:"type"`       // Regular, init or ephemeral container (see shared.ContainerType*)
+	Terminated bool               `bson:"terminated"` // Container has terminated and will not be restarted (init/ephemeral only)
+	Secrets    []string           `bson:"secrets"`    // Names of the secrets exposed to the container via env vars or volume mounts
+	Inherited  ContainerInherited `bson:"inherited"`
+	K8         corev1.Container   `bson:"k8"`
+	Ownership  OwnershipInfo      `bson:"ownership"`
 }
@@ -0,0 +1,15 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Namespace struct {
+	Id         primitive.ObjectID `bson:"_id"`
+	Name       string             `bson:"name"`
+	Labels     map[string]string  `bson:"labels"`
+	PSAEnforce string             `bson:"psa_enforce"`
+	PSAAudit   string             `bson:"psa_audit"`
+	PSAWarn    string             `bson:"psa_warn"`
+	Ownership  OwnershipInfo      `bson:"ownership"`
+}
@@ -0,0 +1,15 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+type NetworkPolicy struct {
+	Id           primitive.ObjectID      `bson:"_id"`
+	Name         string                  `bson:"name"`
+	IsNamespaced bool                    `bson:"is_namespaced"`
+	Namespace    string                  `bson:"namespace"`
+	K8           netv1.NetworkPolicySpec `bson:"k8"`
+	Ownership    OwnershipInfo           `bson:"ownership"`
+}
@@ -0,0 +1,10 @@
+package store
+
+// PersistentVolume holds the resolved source of a K8s persistent volume. Persistent volumes are not written to the
+// store but cached to resolve the persistent volume claims of the pod volumes.
+type PersistentVolume struct {
+	Name         string `bson:"name"`
+	Type         string `bson:"type"`   // Type of the backing volume (see shared.VolumeType*)
+	SourcePath   string `bson:"source"` // Path of the backing volume on the host node
+	StorageClass string `bson:"storage_class"`
+}
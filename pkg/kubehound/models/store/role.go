@@ -6,10 +6,11 @@ import (
 )
 
 type Role struct {
-	Id           primitive.ObjectID  `bson:"_id"`
-	Name         string              `bson:"name"`
-	IsNamespaced bool                `bson:"is_namespaced"`
-	Namespace    string              `bson:"namespace"`
-	Rules        []rbacv1.PolicyRule `bson:"rules"`
-	Ownership    OwnershipInfo       `bson:"ownership"`
+	Id            primitive.ObjectID  `bson:"_id"`
+	Name          string              `bson:"name"`
+	IsNamespaced  bool                `bson:"is_namespaced"`
+	Namespace     string              `bson:"namespace"`
+	Rules         []rbacv1.PolicyRule `bson:"rules"`                    // Effective rules (including aggregated rules)
+	OriginalRules []rbacv1.PolicyRule `bson:"original_rules,omitempty"` // Rules as collected, only set on aggregated cluster roles
+	Ownership     OwnershipInfo       `bson:"ownership"`
 }
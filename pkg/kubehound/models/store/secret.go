@@ -0,0 +1,15 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Secret struct {
+	Id             primitive.ObjectID `bson:"_id"`
+	Name           string             `bson:"name"`
+	IsNamespaced   bool               `bson:"is_namespaced"`
+	Namespace      string             `bson:"namespace"`
+	Type           string             `bson:"type"`
+	ServiceAccount string             `bson:"service_account"`
+	Ownership      OwnershipInfo      `bson:"ownership"`
+}
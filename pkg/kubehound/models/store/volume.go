@@ -6,16 +6,17 @@ import (
 )
 
 type Volume struct {
-	Id          primitive.ObjectID `bson:"_id"`
-	PodId       primitive.ObjectID `bson:"pod_id"`
-	NodeId      primitive.ObjectID `bson:"node_id"`
-	ContainerId primitive.ObjectID `bson:"container_id"`
-	ProjectedId primitive.ObjectID `bson:"projected_id"`
-	Name        string             `bson:"name"`
-	Type        string             `bson:"type"`
-	SourcePath  string             `bson:"source"`
-	MountPath   string             `bson:"mount"`
-	ReadOnly    bool               `bson:"readonly"`
-	Ownership   OwnershipInfo      `bson:"ownership"`
-	K8          corev1.Volume      `bson:"k8"`
+	Id           primitive.ObjectID `bson:"_id"`
+	PodId        primitive.ObjectID `bson:"pod_id"`
+	NodeId       primitive.ObjectID `bson:"node_id"`
+	ContainerId  primitive.ObjectID `bson:"container_id"`
+	ProjectedId  primitive.ObjectID `bson:"projected_id"`
+	Name         string             `bson:"name"`
+	Type         string             `bson:"type"`
+	SourcePath   string             `bson:"source"`
+	MountPath    string             `bson:"mount"`
+	ReadOnly     bool               `bson:"readonly"`
+	StorageClass string             `bson:"storage_class"` // Storage class of the persistent volume, if any
+	Ownership    OwnershipInfo      `bson:"ownership"`
+	K8           corev1.Volume      `bson:"k8"`
 }
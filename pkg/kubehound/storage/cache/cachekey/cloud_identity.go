@@ -0,0 +1,31 @@
+package cachekey
+
+import (
+	"strings"
+)
+
+const (
+	cloudIdentityCacheName = "k8s-cloud-identity"
+)
+
+type cloudIdentityCacheKey struct {
+	baseCacheKey
+}
+
+var _ CacheKey = (*cloudIdentityCacheKey)(nil) // Ensure interface compliance
+
+func CloudIdentity(provider string, identityName string) *cloudIdentityCacheKey {
+	var sb strings.Builder
+
+	sb.WriteString(provider)
+	sb.WriteString(CacheKeySeparator)
+	sb.WriteString(identityName)
+
+	return &cloudIdentityCacheKey{
+		baseCacheKey{sb.String()},
+	}
+}
+
+func (k *cloudIdentityCacheKey) Shard() string {
+	return cloudIdentityCacheName
+}
@@ -0,0 +1,21 @@
+package cachekey
+
+const (
+	persistentVolumeCacheName = "k8s-persistent-volume"
+)
+
+type persistentVolumeCacheKey struct {
+	baseCacheKey
+}
+
+var _ CacheKey = (*persistentVolumeCacheKey)(nil) // Ensure interface compliance
+
+func PersistentVolume(volumeName string) *persistentVolumeCacheKey {
+	return &persistentVolumeCacheKey{
+		baseCacheKey{volumeName},
+	}
+}
+
+func (k *persistentVolumeCacheKey) Shard() string {
+	return persistentVolumeCacheName
+}
@@ -0,0 +1,31 @@
+package cachekey
+
+import (
+	"strings"
+)
+
+const (
+	persistentVolumeClaimCacheName = "k8s-persistent-volume-claim"
+)
+
+type persistentVolumeClaimCacheKey struct {
+	baseCacheKey
+}
+
+var _ CacheKey = (*persistentVolumeClaimCacheKey)(nil) // Ensure interface compliance
+
+func PersistentVolumeClaim(claimName string, namespace string) *persistentVolumeClaimCacheKey {
+	var sb strings.Builder
+
+	sb.WriteString(namespace)
+	sb.WriteString(CacheKeySeparator)
+	sb.WriteString(claimName)
+
+	return &persistentVolumeClaimCacheKey{
+		baseCacheKey{sb.String()},
+	}
+}
+
+func (k *persistentVolumeClaimCacheKey) Shard() string {
+	return persistentVolumeClaimCacheName
+}
@@ -36,6 +36,24 @@ func (r *CacheResult) Role() (*store.Role, error) {
 	return &s, nil
 }
 
+// PersistentVolume returns the result value as a store persistent volume alongside any errors.
+func (r *CacheResult) PersistentVolume() (*store.PersistentVolume, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	if r.Value == nil {
+		return nil, ErrNoEntry
+	}
+
+	pv, ok := r.Value.(store.PersistentVolume)
+	if !ok {
+		return nil, ErrInvalidType
+	}
+
+	return &pv, nil
+}
+
 // Text returns the result value as a string alongside any errors.
 func (r *CacheResult) Text() (string, error) {
 	if r.Err != nil {
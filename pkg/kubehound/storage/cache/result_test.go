@@ -362,3 +362,76 @@ func TestCacheResult_Role(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheResult_PersistentVolume(t *testing.T) {
+	testPersistentVolume := &store.PersistentVolume{
+		Name:         "test-pv",
+		Type:         "HostPath",
+		SourcePath:   "/mnt/disks/ssd1",
+		StorageClass: "local-storage",
+	}
+
+	type fields struct {
+		Value any
+		Err   error
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *store.PersistentVolume
+		wantErr bool
+	}{
+		{
+			name: "success case",
+			fields: fields{
+				Value: *testPersistentVolume,
+				Err:   nil,
+			},
+			want:    testPersistentVolume,
+			wantErr: false,
+		},
+		{
+			name: "error result case",
+			fields: fields{
+				Value: "",
+				Err:   errors.New("test error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "type error case",
+			fields: fields{
+				Value: -1,
+				Err:   nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "nil value case",
+			fields: fields{
+				Value: nil,
+				Err:   nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &CacheResult{
+				Value: tt.fields.Value,
+				Err:   tt.fields.Err,
+			}
+			got, err := r.PersistentVolume()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CacheResult.PersistentVolume() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CacheResult.PersistentVolume() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
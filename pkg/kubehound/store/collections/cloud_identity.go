@@ -0,0 +1,14 @@
+package collections
+
+type CloudIdentity struct {
+}
+
+var _ Collection = (*CloudIdentity)(nil) // Ensure interface compliance
+
+func (c CloudIdentity) Name() string {
+	return CloudIdentityName
+}
+
+func (c CloudIdentity) BatchSize() int {
+	return DefaultBatchSize
+}
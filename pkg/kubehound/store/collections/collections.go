@@ -14,6 +14,10 @@ const (
 	IdentityName      = "identities"
 	PermissionSetName = "permissionsets"
 	EndpointName      = "endpoints"
+	CloudIdentityName = "cloudidentities"
+	NetworkPolicyName = "networkpolicies"
+	NamespaceName     = "namespaces"
+	SecretName        = "secrets"
 )
 
 // Collection provides a common abstraction of a SQL database table or a NoSQL object
@@ -0,0 +1,14 @@
+package collections
+
+type NetworkPolicy struct {
+}
+
+var _ Collection = (*NetworkPolicy)(nil) // Ensure interface compliance
+
+func (c NetworkPolicy) Name() string {
+	return NetworkPolicyName
+}
+
+func (c NetworkPolicy) BatchSize() int {
+	return DefaultBatchSize
+}
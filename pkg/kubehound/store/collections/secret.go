@@ -0,0 +1,14 @@
+package collections
+
+type Secret struct {
+}
+
+var _ Collection = (*Secret)(nil) // Ensure interface compliance
+
+func (c Secret) Name() string {
+	return SecretName
+}
+
+func (c Secret) BatchSize() int {
+	return DefaultBatchSize
+}
@@ -18,13 +18,19 @@ const (
 )
 
 const (
-	MetricCollectorNodesCount               = "kubehound.collector.nodes.count"
-	MetricCollectorEndpointCount            = "kubehound.collector.endpoints.count"
-	MetricCollectorPodsCount                = "kubehound.collector.pods.count"
-	MetricCollectorRolesCount               = "kubehound.collector.roles.count"
-	MetricCollectorRoleBindingsCount        = "kubehound.collector.rolebindings.count"
-	MetricCollectorClusterRolesCount        = "kubehound.collector.clusterroles.count"
-	MetricCollectorClusterRoleBindingsCount = "kubehound.collector.clusterrolebindings.count"
+	MetricCollectorNodesCount                  = "kubehound.collector.nodes.count"
+	MetricCollectorEndpointCount               = "kubehound.collector.endpoints.count"
+	MetricCollectorPodsCount                   = "kubehound.collector.pods.count"
+	MetricCollectorRolesCount                  = "kubehound.collector.roles.count"
+	MetricCollectorRoleBindingsCount           = "kubehound.collector.rolebindings.count"
+	MetricCollectorClusterRolesCount           = "kubehound.collector.clusterroles.count"
+	MetricCollectorClusterRoleBindingsCount    = "kubehound.collector.clusterrolebindings.count"
+	MetricCollectorPersistentVolumesCount      = "kubehound.collector.persistentvolumes.count"
+	MetricCollectorPersistentVolumeClaimsCount = "kubehound.collector.persistentvolumeclaims.count"
+	MetricCollectorServiceAccountsCount        = "kubehound.collector.serviceaccounts.count"
+	MetricCollectorNetworkPoliciesCount        = "kubehound.collector.networkpolicies.count"
+	MetricCollectorNamespacesCount             = "kubehound.collector.namespaces.count"
+	MetricCollectorSecretsCount                = "kubehound.collector.secrets.count"
 
 	MetricStoredbBackgroundWriterCall = "kubehound.storage.storedb.background"
 	MetricStoredbBatchWrite           = "kubehound.storage.storedb.batchwrite.size"
@@ -50,13 +56,19 @@ const (
 	TagKeyLabel    = "label"
 	TagKeyRunId    = "run_id"
 
-	TagResourcePods                = "pods"
-	TagResourceRoles               = "roles"
-	TagResourceRolebindings        = "rolebindings"
-	TagResourceNodes               = "nodes"
-	TagResourceEndpoints           = "endpoints"
-	TagResourceClusterRoles        = "clusterroles"
-	TagResourceClusterRolebindings = "clusterrolebindings"
+	TagResourcePods                   = "pods"
+	TagResourceRoles                  = "roles"
+	TagResourceRolebindings           = "rolebindings"
+	TagResourceNodes                  = "nodes"
+	TagResourceEndpoints              = "endpoints"
+	TagResourceClusterRoles           = "clusterroles"
+	TagResourceClusterRolebindings    = "clusterrolebindings"
+	TagResourcePersistentVolumes      = "persistentvolumes"
+	TagResourcePersistentVolumeClaims = "persistentvolumeclaims"
+	TagResourceServiceAccounts        = "serviceaccounts"
+	TagResourceNetworkPolicies        = "networkpolicies"
+	TagResourceNamespaces             = "namespaces"
+	TagResourceSecrets                = "secrets"
 	// BaseTags represents the minimal tags sent by the application
 	// Each sub-component of the app will add to their local usage their own tags depending on their needs.
 )
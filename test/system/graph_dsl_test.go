@@ -96,20 +96,31 @@ func (suite *DslTestSuite) TestTraversalSource_escapes() {
 	expected := []string{
 		"path[kube-proxy, CE_MODULE_LOAD, Node]",
 		"path[kube-proxy, CE_PRIV_MOUNT, Node]",
+		"path[kube-proxy, CE_UMH_CORE_PATTERN, Node]",
 		"path[sys-ptrace-pod, CE_SYS_PTRACE, Node]",
+		"path[sys-ptrace-pod, CE_CGROUP_RELEASE_AGENT, Node]",
+		"path[cgroup-release-pod, CE_CGROUP_RELEASE_AGENT, Node]",
+		"path[dac-read-search-pod, CE_DAC_READ_SEARCH, Node]",
 		"path[priv-pod, CE_MODULE_LOAD, Node]",
 		"path[priv-pod, CE_PRIV_MOUNT, Node]",
+		"path[priv-pod, CE_UMH_CORE_PATTERN, Node]",
 		"path[nsenter-pod, CE_NSENTER, Node]",
 		"path[nsenter-pod, CE_MODULE_LOAD, Node]",
 		"path[nsenter-pod, CE_PRIV_MOUNT, Node]",
+		"path[nsenter-pod, CE_UMH_CORE_PATTERN, Node]",
 		"path[kube-proxy, CE_MODULE_LOAD, Node]",
 		"path[kube-proxy, CE_PRIV_MOUNT, Node]",
+		"path[kube-proxy, CE_UMH_CORE_PATTERN, Node]",
 		"path[endpoints-pod, CE_NSENTER, Node]",
 		"path[endpoints-pod, CE_MODULE_LOAD, Node]",
 		"path[endpoints-pod, CE_PRIV_MOUNT, Node]",
+		"path[endpoints-pod, CE_UMH_CORE_PATTERN, Node]",
+		"path[umh-core-pod, CE_UMH_CORE_PATTERN, Node]",
 		"path[modload-pod, CE_MODULE_LOAD, Node]",
+		"path[containerd-sock-pod, EXPLOIT_CONTAINERD_SOCK, Node]",
 		"path[kube-proxy, CE_MODULE_LOAD, Node]",
 		"path[kube-proxy, CE_PRIV_MOUNT, Node]",
+		"path[kube-proxy, CE_UMH_CORE_PATTERN, Node]",
 	}
 
 	suite.ElementsMatch(escapes, expected)
@@ -156,18 +167,33 @@ func (suite *DslTestSuite) TestTraversalSource_hostMounts() {
 	mounts := suite.testScriptArray("kh.hostMounts().has('namespace', 'default').values('name')")
 	expected := make([]string, 0)
 
+	// Volumes not backed by a host path are not returned as host mounts
+	nonHostVolumes := map[string]bool{
+		"shared-data":  true, // emptyDir
+		"legacy-token": true, // secret
+	}
+
 	for k, _ := range expectedVolumes {
+		if nonHostVolumes[k] {
+			continue
+		}
+
 		expected = append(expected, k)
 	}
 
+	// The shared host path volume is mounted by both the writer and reader pods
+	expected = append(expected, "shared-host")
+
 	suite.ElementsMatch(mounts, expected)
 }
 
 func (suite *DslTestSuite) TestTraversalSource_identities() {
 	ids := suite.testScriptArray("kh.identities().has('namespace', 'default').values('name')")
 	expected := []string{
-		"varrlog-sa", "rolebind-sa", "tokenget-sa", "pod-create-sa",
+		"varlog-sa", "rolebind-sa", "tokenget-sa", "pod-create-sa",
 		"impersonate-sa", "pod-exec-sa", "tokenlist-sa", "pod-patch-sa",
+		"workload-create-sa", "pod-debug-sa", "node-proxy-sa", "csr-approve-sa",
+		"token-create-sa", "webhook-tamper-sa", "pod-port-forward-sa", "cloud-identity-sa",
 	}
 
 	suite.ElementsMatch(ids, expected)
@@ -176,8 +202,10 @@ func (suite *DslTestSuite) TestTraversalSource_identities() {
 func (suite *DslTestSuite) TestTraversalSource_sas() {
 	ids := suite.testScriptArray("kh.sas().has('namespace', 'default').values('name')")
 	expected := []string{
-		"varrlog-sa", "rolebind-sa", "tokenget-sa", "pod-create-sa",
+		"varlog-sa", "rolebind-sa", "tokenget-sa", "pod-create-sa",
 		"impersonate-sa", "pod-exec-sa", "tokenlist-sa", "pod-patch-sa",
+		"workload-create-sa", "pod-debug-sa", "node-proxy-sa", "csr-approve-sa",
+		"token-create-sa", "webhook-tamper-sa", "pod-port-forward-sa", "cloud-identity-sa",
 	}
 
 	suite.ElementsMatch(ids, expected)
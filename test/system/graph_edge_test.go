@@ -2,6 +2,7 @@ package system
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/DataDog/KubeHound/pkg/config"
@@ -96,6 +97,45 @@ func (suite *EdgeTestSuite) _testContainerEscape(edgeLabel string, nodes map[str
 	suite.True(matched)
 }
 
+func (suite *EdgeTestSuite) TestEdge_CE_CGROUP_RELEASE_AGENT() {
+	containers := map[string]bool{
+		"cgroup-release-pod": true,
+		"sys-ptrace-pod":     true,
+	}
+
+	suite._testContainerEscape("CE_CGROUP_RELEASE_AGENT", DefaultContainerEscapeNodes, containers)
+
+	// Containers confined by AppArmor profiles blocking the mount syscall should not be escapable
+	results, err := suite.g.V().
+		Has("class", "Container").
+		Has("name", "cgroup-release-confined-pod").
+		OutE("CE_CGROUP_RELEASE_AGENT").
+		ToList()
+
+	suite.NoError(err)
+	suite.Empty(results)
+}
+
+func (suite *EdgeTestSuite) TestEdge_CE_DAC_READ_SEARCH() {
+	containers := map[string]bool{
+		"dac-read-search-pod": true,
+	}
+
+	suite._testContainerEscape("CE_DAC_READ_SEARCH", DefaultContainerEscapeNodes, containers)
+
+	// Host read access should chain to the theft of the tokens mounted on the node
+	results, err := suite.g.V().
+		Has("class", "Container").
+		Has("name", "dac-read-search-pod").
+		OutE("CE_DAC_READ_SEARCH").InV().
+		OutE("VOLUME_ACCESS").InV().
+		OutE("TOKEN_STEAL").InV().
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+}
+
 func (suite *EdgeTestSuite) TestEdge_CE_MODULE_LOAD() {
 	containers := map[string]bool{
 		"modload-pod": true,
@@ -128,6 +168,59 @@ func (suite *EdgeTestSuite) TestEdge_CE_SYS_PTRACE() {
 	suite._testContainerEscape("CE_SYS_PTRACE", DefaultContainerEscapeNodes, containers)
 }
 
+func (suite *EdgeTestSuite) TestEdge_CE_UMH_CORE_PATTERN() {
+	containers := map[string]bool{
+		"umh-core-pod": true,
+		"priv-pod":     true,
+	}
+
+	suite._testContainerEscape("CE_UMH_CORE_PATTERN", DefaultContainerEscapeNodes, containers)
+}
+
+func (suite *EdgeTestSuite) TestEdge_EXPLOIT_CONTAINERD_SOCK() {
+	containers := map[string]bool{
+		"containerd-sock-pod": true,
+	}
+
+	suite._testContainerEscape("EXPLOIT_CONTAINERD_SOCK", DefaultContainerEscapeNodes, containers)
+}
+
+func (suite *EdgeTestSuite) TestEdge_CE_NET_MITM() {
+	results, err := suite.g.V().
+		HasLabel("Container").
+		Has("name", "netadmin-pod").
+		OutE().HasLabel("CE_NET_MITM").
+		InV().HasLabel("Container").
+		Values("name").
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	// The attacker container should never be linked to itself
+	containers := suite.resultsToStringArray(results)
+	suite.NotContains(containers, "netadmin-pod")
+
+	// The NET_RAW capability is granted by default unless explicitly dropped
+	results, err = suite.g.V().
+		HasLabel("Container").
+		Has("name", "netraw-pod").
+		OutE().HasLabel("CE_NET_MITM").
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	results, err = suite.g.V().
+		HasLabel("Container").
+		Has("name", "netraw-drop-pod").
+		OutE().HasLabel("CE_NET_MITM").
+		ToList()
+
+	suite.NoError(err)
+	suite.Empty(results)
+}
+
 func (suite *EdgeTestSuite) TestEdge_CONTAINER_ATTACH() {
 	// Every container should have a CONTAINER_ATTACH incoming from a pod
 	rawCount, err := suite.g.V().
@@ -209,6 +302,37 @@ func (suite *EdgeTestSuite) TestEdge_IDENTITY_ASSUME_Node() {
 	suite.Subset(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_IDENTITY_ASSUME_CLOUD() {
+	// We have two bespoke service accounts bound to the same AWS IAM role via their annotation, both reaching the same
+	// cloud identity vertex. Kind nodes do not run on a cloud instance so no node should reach a cloud identity.
+	results, err := suite.g.V().
+		HasLabel("Identity").
+		OutE().HasLabel("IDENTITY_ASSUME_CLOUD").
+		InV().HasLabel("CloudIdentity").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[cloud-identity-sa]], map[], map[name:[arn:aws:iam::123456789012:role/kubehound-cloud-identity]",
+		"path[map[name:[cloud-identity-shared-sa]], map[], map[name:[arn:aws:iam::123456789012:role/kubehound-cloud-identity]",
+	}
+	suite.ElementsMatch(paths, expected)
+
+	rawCount, err := suite.g.V().
+		HasLabel("Node").
+		OutE().HasLabel("IDENTITY_ASSUME_CLOUD").
+		Count().Next()
+
+	suite.NoError(err)
+	nodeCount, err := rawCount.GetInt()
+	suite.NoError(err)
+	suite.Equal(nodeCount, 0)
+}
+
 func (suite *EdgeTestSuite) TestEdge_POD_ATTACH() {
 	// Every pod should have a POD_ATTACH incoming from a node
 	rawCount, err := suite.g.V().
@@ -293,6 +417,99 @@ func (suite *EdgeTestSuite) TestEdge_POD_CREATE() {
 	suite.Subset(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_POD_CREATE_PodSecurity() {
+	// The same pod/create permissions bound within a namespace enforcing the baseline pod security standards cannot
+	// create a privileged pod, so should not reach any node
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("namespace", "psa-baseline").
+		Has("name", "create-pods::pod-create-pods-baseline").
+		ToList()
+
+	suite.NoError(err)
+	suite.Len(results, 1)
+
+	results, err = suite.g.V().
+		HasLabel("PermissionSet").
+		Has("namespace", "psa-baseline").
+		Has("name", "create-pods::pod-create-pods-baseline").
+		OutE().HasLabel("POD_CREATE").
+		ToList()
+
+	suite.NoError(err)
+	suite.Empty(results)
+}
+
+func (suite *EdgeTestSuite) TestEdge_WEBHOOK_TAMPER() {
+	// We have one bespoke cluster role binding with mutatingwebhookconfigurations/create permissions which should reach
+	// all nodes since any pod created in the cluster can be rewritten
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("name", "tamper-webhooks::webhook-tamper-webhooks").
+		OutE().HasLabel("WEBHOOK_TAMPER").
+		InV().HasLabel("Node").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 3)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[tamper-webhooks::webhook-tamper-webhooks]], map[], map[name:[kubehound.test.local-control-plane]",
+		"path[map[name:[tamper-webhooks::webhook-tamper-webhooks]], map[], map[name:[kubehound.test.local-worker]",
+		"path[map[name:[tamper-webhooks::webhook-tamper-webhooks]], map[], map[name:[kubehound.test.local-worker2]",
+	}
+	suite.Subset(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_WORKLOAD_CREATE() {
+	// We have one bespoke container running with deployments/create permissions which should reach all nodes
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("namespace", "default").
+		OutE().HasLabel("WORKLOAD_CREATE").
+		InV().HasLabel("Node").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 3)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[create-deployments::pod-create-deployments]], map[], map[name:[kubehound.test.local-control-plane]",
+		"path[map[name:[create-deployments::pod-create-deployments]], map[], map[name:[kubehound.test.local-worker]",
+		"path[map[name:[create-deployments::pod-create-deployments]], map[], map[name:[kubehound.test.local-worker2]",
+	}
+	suite.Subset(paths, expected)
+
+	// The deployments creation permission should not leak into other workload kinds
+	results, err = suite.g.V().
+		HasLabel("PermissionSet").
+		Has("name", "create-deployments::pod-create-deployments").
+		OutE().HasLabel("WORKLOAD_CREATE").
+		Values("kinds").
+		Dedup().
+		ToList()
+
+	suite.NoError(err)
+	suite.Len(results, 1)
+	suite.Equal("[Deployment]", fmt.Sprintf("%v", results[0].GetInterface()))
+
+	// Nor into pod creation
+	results, err = suite.g.V().
+		HasLabel("PermissionSet").
+		Has("name", "create-deployments::pod-create-deployments").
+		OutE().HasLabel("POD_CREATE").
+		ToList()
+
+	suite.NoError(err)
+	suite.Empty(results)
+}
+
 func (suite *EdgeTestSuite) TestEdge_POD_EXEC() {
 	// We have one bespoke container running with pod/exec permissions which should reach all pods in the namespace
 	results, err := suite.g.V().
@@ -328,6 +545,196 @@ func (suite *EdgeTestSuite) TestEdge_POD_EXEC() {
 	suite.Subset(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_CSR_APPROVE() {
+	// We have one bespoke service account able to create and approve certificate signing requests for the API server
+	// client signer, which should reach the node and system:masters identities
+	results, err := suite.g.V().
+		HasLabel("Identity").
+		Has("name", "csr-approve-sa").
+		OutE().HasLabel("CSR_APPROVE").
+		InV().HasLabel("Identity").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 2)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[csr-approve-sa]], map[], map[name:[system:masters]",
+		"path[map[name:[csr-approve-sa]], map[], map[name:[system:nodes]",
+	}
+	suite.Subset(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_NODE_PROXY() {
+	// We have one bespoke cluster role binding with nodes/proxy permissions which should reach all nodes
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("name", "proxy-nodes::node-proxy-nodes").
+		OutE().HasLabel("NODE_PROXY").
+		InV().HasLabel("Node").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 3)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[proxy-nodes::node-proxy-nodes]], map[], map[name:[kubehound.test.local-control-plane]",
+		"path[map[name:[proxy-nodes::node-proxy-nodes]], map[], map[name:[kubehound.test.local-worker]",
+		"path[map[name:[proxy-nodes::node-proxy-nodes]], map[], map[name:[kubehound.test.local-worker2]",
+	}
+	suite.Subset(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_POD_DEBUG() {
+	// We have one bespoke container running with pods/ephemeralcontainers permissions which should reach all pods in
+	// the namespace
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("namespace", "default").
+		OutE().HasLabel("POD_DEBUG").
+		InV().HasLabel("Pod").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[modload-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[priv-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[pod-exec-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[pod-debug-pod]",
+	}
+	suite.Subset(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_POD_PORT_FORWARD() {
+	// We have one bespoke container running with pods/portforward permissions restricted to the endpoints pod, which
+	// should only reach its private container endpoint (the service and host port endpoints are not private)
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("namespace", "default").
+		OutE().HasLabel("POD_PORT_FORWARD").
+		InV().HasLabel("Endpoint").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[port-forward-pods::pod-port-forward-pods]], map[], map[name:[default::endpoints-pod::TCP::9999]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_SERVICE_ACCOUNT_TOKEN_CREATE() {
+	// We have one bespoke container running with serviceaccounts/token permissions restricted to two service accounts
+	// of the namespace
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("namespace", "default").
+		OutE().HasLabel("SERVICE_ACCOUNT_TOKEN_CREATE").
+		InV().HasLabel("Identity").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[create-tokens::pod-create-tokens]], map[], map[name:[impersonate-sa]",
+		"path[map[name:[create-tokens::pod-create-tokens]], map[], map[name:[rolebind-sa]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_ROLE_BIND() {
+	// We have one bespoke container running with rolebindings/create and roles/bind permissions which should reach all
+	// other permission sets in the namespace
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("namespace", "default").
+		OutE().HasLabel("ROLE_BIND").
+		InV().HasLabel("PermissionSet").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[rolebind::pod-bind-role]], map[], map[name:[exec-pods::pod-exec-pods]",
+		"path[map[name:[rolebind::pod-bind-role]], map[], map[name:[patch-pods::pod-patch-pods]",
+		"path[map[name:[rolebind::pod-bind-role]], map[], map[name:[create-pods::pod-create-pods]",
+		"path[map[name:[rolebind::pod-bind-role]], map[], map[name:[read-secrets::pod-get-secrets]",
+		"path[map[name:[rolebind::pod-bind-role]], map[], map[name:[list-secrets::pod-list-secrets]",
+		"path[map[name:[rolebind::pod-bind-role]], map[], map[name:[impersonate::pod-impersonate]",
+	}
+	suite.Subset(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_IDENTITY_IMPERSONATE() {
+	// We have one bespoke container running with a namespaced impersonate permission which should reach all
+	// service accounts in the namespace
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("namespace", "default").
+		OutE().HasLabel("IDENTITY_IMPERSONATE").
+		InV().HasLabel("Identity").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 7)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[impersonate::pod-impersonate]], map[], map[name:[pod-patch-sa]",
+		"path[map[name:[impersonate::pod-impersonate]], map[], map[name:[impersonate-sa]",
+		"path[map[name:[impersonate::pod-impersonate]], map[], map[name:[tokenlist-sa]",
+		"path[map[name:[impersonate::pod-impersonate]], map[], map[name:[pod-exec-sa]",
+		"path[map[name:[impersonate::pod-impersonate]], map[], map[name:[tokenget-sa]",
+		"path[map[name:[impersonate::pod-impersonate]], map[], map[name:[rolebind-sa]",
+		"path[map[name:[impersonate::pod-impersonate]], map[], map[name:[pod-create-sa]",
+	}
+	suite.Subset(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_IDENTITY_IMPERSONATE_Extras() {
+	// Impersonating uids and user extras cannot be used to assume an identity on its own, so should not reach any
+	// identity
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("name", "impersonate-extras::pod-impersonate-extras").
+		ToList()
+
+	suite.NoError(err)
+	suite.Len(results, 1)
+
+	results, err = suite.g.V().
+		HasLabel("PermissionSet").
+		Has("name", "impersonate-extras::pod-impersonate-extras").
+		OutE().HasLabel("IDENTITY_IMPERSONATE").
+		ToList()
+
+	suite.NoError(err)
+	suite.Empty(results)
+}
+
 func (suite *EdgeTestSuite) TestEdge_PERMISSION_DISCOVER() {
 
 	// We currently have 6 custom accounts configured (excluding the default)
@@ -361,7 +768,7 @@ func (suite *EdgeTestSuite) TestEdge_PERMISSION_DISCOVER() {
 		"path[map[name:[tokenlist-sa]], map[], map[name:[list-secrets::pod-list-secrets]",
 		"path[map[name:[pod-exec-sa]], map[], map[name:[exec-pods::pod-exec-pods]",
 		"path[map[name:[impersonate-sa]], map[], map[name:[impersonate::pod-impersonate]",
-		"path[map[name:[varrlog-sa]], map[], map[name:[read-logs::pod-read-logs]",
+		"path[map[name:[varlog-sa]], map[], map[name:[read-logs::pod-read-logs]",
 	}
 
 	suite.Subset(paths, expected)
@@ -469,6 +876,46 @@ func (suite *EdgeTestSuite) TestEdge_TOKEN_LIST() {
 	suite.Subset(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_TOKEN_LIST_Secret() {
+	results, err := suite.g.V().
+		HasLabel("PermissionSet").
+		Has("namespace", "default").
+		OutE().HasLabel("TOKEN_LIST").
+		InV().HasLabel("Secret").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[tokenlist-sa-token]",
+	}
+	suite.Subset(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_IDENTITY_ASSUME_Secret() {
+	results, err := suite.g.V().
+		HasLabel("Secret").
+		Has("namespace", "default").
+		OutE().HasLabel("IDENTITY_ASSUME").
+		InV().HasLabel("Identity").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[tokenlist-sa-token]], map[], map[name:[tokenlist-sa]",
+	}
+	suite.Subset(paths, expected)
+}
+
 func (suite *EdgeTestSuite) TestEdge_TOKEN_STEAL() {
 	// Every pod in our test cluster should have projected volume holding a token. BUT we only
 	// save those with a non-default service account token as shown below.
@@ -492,6 +939,46 @@ func (suite *EdgeTestSuite) TestEdge_TOKEN_STEAL() {
 	suite.Subset(identities, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_TOKEN_STEAL_Secret() {
+	results, err := suite.g.V().
+		HasLabel("Container").
+		OutE().HasLabel("TOKEN_STEAL").
+		InV().HasLabel("Identity").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 2)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[tokensteal-secret-volume-pod]], map[], map[name:[tokenlist-sa]",
+		"path[map[name:[tokensteal-secret-env-pod]], map[], map[name:[tokenlist-sa]",
+	}
+	suite.Subset(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_TOKEN_VAR_LOG_SYMLINK() {
+	// The container own service account token is always projected on the same node
+	results, err := suite.g.V().
+		HasLabel("Container").
+		Has("name", "varlog-pod").
+		OutE().HasLabel("TOKEN_VAR_LOG_SYMLINK").
+		InV().HasLabel("Identity").
+		Values("name").
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	identities := suite.resultsToStringArray(results)
+	expected := []string{
+		"varlog-sa",
+	}
+	suite.Subset(identities, expected)
+}
+
 func (suite *EdgeTestSuite) TestEdge_EXPLOIT_HOST_READ() {
 	results, err := suite.g.V().
 		HasLabel("Container").
@@ -509,6 +996,7 @@ func (suite *EdgeTestSuite) TestEdge_EXPLOIT_HOST_READ() {
 	paths := suite.pathsToStringArray(results)
 	expected := []string{
 		"path[map[name:[host-read-exploit-pod]], map[], map[name:[host-ssh]",
+		"path[map[name:[host-pvc-read-pod]], map[], map[name:[host-etc-claim]",
 	}
 	suite.Subset(paths, expected)
 }
@@ -640,6 +1128,49 @@ func (suite *EdgeTestSuite) TestEdge_ENDPOINT_EXPLOIT_External() {
 	suite.Subset(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_ENDPOINT_EXPLOIT_Reachable() {
+	// The endpoints pod is isolated by a network policy allowing ingress to the service port from all sources, to the
+	// host port from the pods of its namespace, and to the internal port from no pod of the cluster
+	results, err := suite.g.V().
+		HasLabel("Endpoint").
+		Has("namespace", "default").
+		Where(
+			__.OutE("ENDPOINT_EXPLOIT").
+				Has("reachable", true).
+				InV().
+				Has("name", "endpoints-pod")).
+		Values("serviceEndpoint").
+		ToList()
+
+	suite.NoError(err)
+
+	paths := suite.resultsToStringArray(results)
+	expected := []string{
+		"webproxy-service",
+		"host-port-svc",
+	}
+	suite.ElementsMatch(paths, expected)
+
+	results, err = suite.g.V().
+		HasLabel("Endpoint").
+		Has("namespace", "default").
+		Where(
+			__.OutE("ENDPOINT_EXPLOIT").
+				Has("reachable", false).
+				InV().
+				Has("name", "endpoints-pod")).
+		Values("serviceEndpoint").
+		ToList()
+
+	suite.NoError(err)
+
+	paths = suite.resultsToStringArray(results)
+	expected = []string{
+		"jmx",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
 func (suite *EdgeTestSuite) TestEdge_SHARE_PS_NAMESPACE() {
 	results, err := suite.g.V().
 		HasLabel("Container").
@@ -669,6 +1200,29 @@ func (suite *EdgeTestSuite) TestEdge_SHARE_PS_NAMESPACE() {
 	suite.ElementsMatch(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_VOLUME_SHARE() {
+	// We have one bespoke pod with a writer and reader container sharing an emptyDir volume, and two bespoke pods on
+	// the same node with a writer and reader container sharing a hostPath volume
+	results, err := suite.g.V().
+		HasLabel("Container").
+		Has("name", P.Within("volume-share-writer", "volume-share-reader", "volume-share-host-writer",
+			"volume-share-host-reader")).
+		OutE().HasLabel("VOLUME_SHARE").
+		InV().HasLabel("Container").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[volume-share-writer]], map[], map[name:[volume-share-reader]",
+		"path[map[name:[volume-share-host-writer]], map[], map[name:[volume-share-host-reader]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
 func (suite *EdgeTestSuite) Test_NoEdgeCase() {
 	// The control pod has no interesting properties and therefore should have NO outgoing edges
 	results, err := suite.g.V().
@@ -296,7 +296,7 @@ func (suite *VertexTestSuite) TestVertexCritical() {
 func (suite *VertexTestSuite) TestVertexVolume() {
 	results, err := suite.g.V().HasLabel(vertex.VolumeLabel).ElementMap().ToList()
 	suite.NoError(err)
-	suite.Equal(51, len(results))
+	suite.Equal(68, len(results))
 
 	results, err = suite.g.V().HasLabel(vertex.VolumeLabel).Has("sourcePath", "/proc/sys/kernel").Has("name", "nodeproc").ElementMap().ToList()
 	suite.NoError(err)
@@ -309,6 +309,21 @@ func (suite *VertexTestSuite) TestVertexVolume() {
 	results, err = suite.g.V().HasLabel(vertex.VolumeLabel).Has("sourcePath", "/var/log").Has("name", "nodelog").ElementMap().ToList()
 	suite.NoError(err)
 	suite.Equal(len(results), 1)
+
+	// Persistent volume claims are resolved to the underlying host path
+	results, err = suite.g.V().HasLabel(vertex.VolumeLabel).Has("sourcePath", "/etc").Has("name", "host-etc-claim").ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(len(results), 1)
+
+	// EmptyDir volumes are ingested for each container mounting them
+	results, err = suite.g.V().HasLabel(vertex.VolumeLabel).Has("type", shared.VolumeTypeEmptyDir).Has("name", "shared-data").ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(len(results), 2)
+
+	// Secret volumes are ingested as read-only volumes, without the secret values
+	results, err = suite.g.V().HasLabel(vertex.VolumeLabel).Has("type", shared.VolumeTypeSecret).Has("name", "legacy-token").Has("readonly", true).ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(len(results), 1)
 }
 
 func (suite *VertexTestSuite) TestVertexIdentity() {
@@ -339,6 +354,59 @@ func (suite *VertexTestSuite) TestVertexIdentity() {
 	results, err = suite.g.V().HasLabel(vertex.IdentityLabel).Has("name", "pod-create-sa").ElementMap().ToList()
 	suite.NoError(err)
 	suite.Equal(len(results), 1)
+
+	// Service accounts bound to a cloud identity are ingested even without any role binding
+	results, err = suite.g.V().HasLabel(vertex.IdentityLabel).Has("name", "cloud-identity-sa").ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(len(results), 1)
+}
+
+func (suite *VertexTestSuite) TestVertexCloudIdentity() {
+	// Kind nodes do not run on a cloud instance so we only expect the workload identity shared by the bespoke service
+	// accounts
+	results, err := suite.g.V().HasLabel(vertex.CloudIdentityLabel).ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(len(results), 1)
+
+	results, err = suite.g.V().HasLabel(vertex.CloudIdentityLabel).
+		Has("name", "arn:aws:iam::123456789012:role/kubehound-cloud-identity").
+		Has("isNamespaced", false).
+		Has("provider", "AWS").
+		Has("type", "Workload").
+		ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(len(results), 1)
+}
+
+func (suite *VertexTestSuite) TestVertexNamespace() {
+	results, err := suite.g.V().HasLabel(vertex.NamespaceLabel).
+		Has("name", "psa-baseline").
+		Has("psaEnforce", "baseline").
+		Has("psaAudit", "privileged").
+		Has("psaWarn", "restricted").
+		ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(len(results), 1)
+
+	// Namespaces without pod security labels default to the privileged level
+	results, err = suite.g.V().HasLabel(vertex.NamespaceLabel).
+		Has("name", "default").
+		Has("psaEnforce", "privileged").
+		ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(len(results), 1)
+}
+
+func (suite *VertexTestSuite) TestVertexSecret() {
+	// Only the metadata of secrets is collected, the legacy token secret of the TOKEN_LIST fixture is linked to its service account
+	results, err := suite.g.V().HasLabel(vertex.SecretLabel).
+		Has("name", "tokenlist-sa-token").
+		Has("namespace", "default").
+		Has("type", "kubernetes.io/service-account-token").
+		Has("serviceAccount", "tokenlist-sa").
+		ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(len(results), 1)
 }
 
 func TestVertexTestSuite(t *testing.T) {
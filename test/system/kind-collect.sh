@@ -21,20 +21,39 @@ RESOURCES_ALLOWLIST=(
     pods
     roles*
     rolebinding*
+    persistentvolumeclaims
+    serviceaccounts
+    networkpolicies.networking.k8s.io
 )
 
 CLUSTER_RESOURCES=(
     nodes
     clusterroles.rbac.authorization.k8s.io
     clusterrolebindings.rbac.authorization.k8s.io
+    persistentvolumes
+    namespaces
 )
 
 #
 
+# built-in secret types, secrets of any other (custom) type are matched by excluding all of them
+SECRET_TYPES=(
+    Opaque
+    kubernetes.io/service-account-token
+    kubernetes.io/dockercfg
+    kubernetes.io/dockerconfigjson
+    kubernetes.io/basic-auth
+    kubernetes.io/ssh-auth
+    kubernetes.io/tls
+    bootstrap.kubernetes.io/token
+)
+
 #
 # CLI FLAGS VARIABLES
 #
 ALLOWLIST_MODE=1
+# collect the secrets metadata (names, types and annotations) if 1, secret values are never requested
+SECRETS=0
 # fetch only missing resources if 1
 UPDATE=0
 # how long should the script wait between 2 calls to "kubectl get <resource> -n <namespace> -o json"
@@ -48,8 +67,13 @@ DELAY=0
 STARTUP_DELAY=0
 # temporary file for storing kubectl errors
 ERRFILE="/tmp/get-cluster-data-errors.log"
+# local port of the kubectl proxy used to collect the secrets metadata, picked at random when the proxy starts
+PROXY_PORT=""
+# how many times the proxy readiness should be checked (every 0.1sec) before giving up
+PROXY_RETRIES=100
 # patterns matching resources we don't want to collect (yet?)
 RESOURCES_DENYLIST=(
+    secrets
     *.istio.io
     datadogmetric*
     *.containo.us
@@ -65,13 +89,73 @@ function green() {
     echo -ne "\033[1;32m${1}\033[0m"
 }
 
+# list the metadata of the secrets of a namespace matching a field selector, as a secret list of the given type.
+# Secrets are requested as PartialObjectMetadataList so the secret values are never sent by the API server.
+function list_secrets_metadata() {
+    local namespace="${1}"
+    local selector="${2}"
+    local type="${3}"
+
+    curl -sf -G "http://127.0.0.1:${PROXY_PORT}/api/v1/namespaces/${namespace}/secrets" \
+        --data-urlencode "fieldSelector=${selector}" \
+        -H "Accept: application/json;as=PartialObjectMetadataList;g=meta.k8s.io;v=v1" \
+        | jq --arg type "${type}" '{items: [.items[] | {
+            metadata: (.metadata | del(.managedFields, .annotations["kubectl.kubernetes.io/last-applied-configuration"])),
+            type: $type
+        }]}'
+}
+
+# extract the metadata of all the secrets of a namespace into a single secret list file (see list_secrets_metadata)
+function extract_secrets_metadata() {
+    local namespace="${1}"
+    local outfile="${2}"
+    local custom=""
+
+    {
+        for type in "${SECRET_TYPES[@]}"; do
+            list_secrets_metadata "${namespace}" "type=${type}" "${type}"
+            custom="${custom:+${custom},}type!=${type}"
+        done
+        # secrets of a custom type are reported without a type
+        list_secrets_metadata "${namespace}" "${custom}" ""
+    } | jq -s '{kind: "SecretList", apiVersion: "v1", items: ((map(.items) | add) // [])}' > "${outfile}"
+}
+
+# start a kubectl proxy on a random local port, and wait until it serves requests. Sets PROXY_PORT and proxy_pid.
+function start_proxy() {
+    local proxy_log
+    proxy_log="$(mktemp)"
+
+    kubectl proxy --port=0 > "${proxy_log}" 2>&1 &
+    proxy_pid=$!
+
+    PROXY_PORT=""
+    for _ in $(seq "${PROXY_RETRIES}"); do
+        if [[ -z "${PROXY_PORT}" ]]; then
+            PROXY_PORT="$(sed -n 's/^Starting to serve on .*:\([0-9]*\)$/\1/p' "${proxy_log}")"
+        fi
+        if [[ -n "${PROXY_PORT}" ]] && curl -sf "http://127.0.0.1:${PROXY_PORT}/version" > /dev/null; then
+            rm -f "${proxy_log}"
+            return 0
+        fi
+        sleep 0.1
+    done
+
+    cat "${proxy_log}"
+    rm -f "${proxy_log}"
+    kill "${proxy_pid}" 2> /dev/null
+    return 1
+}
+
 # arguments handling helpers
 function show_usage() {
     cat << EOF
-Usage ${0} [-ua] [-d DELAY] OUTDIR
+Usage ${0} [-uas] [-d DELAY] OUTDIR
     -u              Update partial extract, only fetch missing resources
     -d DELAY        Delay between api calls (default: ${DELAY}sec)
     -a              Allowlist mode
+    -s              Collect secrets metadata (names, types and annotations), secret values are never requested.
+                    Requires curl and jq, and uses a kubectl proxy on a random local port
     -h              Show this help message
 EOF
 }
@@ -79,7 +163,7 @@ EOF
 # record start date and parse arguments
 start_time="$(date +'%Y-%m-%d %H:%M:%S')"
 OPTIND=1
-while getopts "d:uah" opt; do
+while getopts "d:uahs" opt; do
     case "${opt}" in
         d)
             DELAY="${OPTARG}"
@@ -90,6 +174,9 @@ while getopts "d:uah" opt; do
         a)
             ALLOWLIST_MODE=1
             ;;
+        s)
+            SECRETS=1
+            ;;
         *)
             show_usage
             exit 1
@@ -152,6 +239,15 @@ for cluster in "${TARGET_CLUSTERS[@]}"; do
         exit 1
     fi
 
+    # secrets metadata is requested through a kubectl proxy to set the partial object metadata accept header
+    if [[ ${SECRETS} -eq 1 ]]; then
+        start_proxy
+        if [[ $? -ne 0 ]]; then
+            echo "Could not start a kubectl proxy to the $(red "${cluster}") cluster, aborting"
+            exit 1
+        fi
+    fi
+
     # handle cluster-level resources
     cluster_dir="${OUTDIR}/${cluster}"
     mkdir "${cluster_dir}"
@@ -259,8 +355,21 @@ for cluster in "${TARGET_CLUSTERS[@]}"; do
             echo "  - $(green "${resource}") ($(green "${res_current}")/${res_count})"
             res_current=$((res_current+1))
         done
+
+        # extract secrets metadata
+        if [[ ${SECRETS} -eq 1 ]]; then
+            ns_dir="${cluster_dir}/${namespace}"
+            mkdir -p "${ns_dir}"
+            sleep "${DELAY}"
+            extract_secrets_metadata "${namespace}" "${ns_dir}/secrets.json"
+            echo "  - $(green "secrets") (metadata only)"
+        fi
         echo ""
     done
+
+    if [[ ${SECRETS} -eq 1 ]]; then
+        kill "${proxy_pid}"
+    fi
 done
 
 # cleanup
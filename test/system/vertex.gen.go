@@ -1,5 +1,5 @@
 // PLEASE DO NOT EDIT
-// THIS HAS BEEN GENERATED AUTOMATICALLY on 2023-09-19 16:48
+// THIS HAS BEEN GENERATED AUTOMATICALLY on 2026-10-18 13:53
 //
 // Generate it with "go generate ./..."
 //
@@ -19,6 +19,46 @@ import (
 )
 
 var expectedPods = map[string]graph.Pod{
+	"cgroup-release-confined-pod": {
+		StoreID:               "",
+		Name:                  "cgroup-release-confined-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"cgroup-release-pod": {
+		StoreID:               "",
+		Name:                  "cgroup-release-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"cloud-identity-pod": {
+		StoreID:               "",
+		Name:                  "cloud-identity-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "cloud-identity-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"containerd-sock-pod": {
+		StoreID:               "",
+		Name:                  "containerd-sock-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"control-pod": {
 		StoreID:               "",
 		Name:                  "control-pod",
@@ -29,6 +69,26 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"csr-approve-pod": {
+		StoreID:               "",
+		Name:                  "csr-approve-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "csr-approve-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"dac-read-search-pod": {
+		StoreID:               "",
+		Name:                  "dac-read-search-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"endpoints-pod": {
 		StoreID:               "",
 		Name:                  "endpoints-pod",
@@ -39,6 +99,16 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"host-pvc-read-pod": {
+		StoreID:               "",
+		Name:                  "host-pvc-read-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"host-read-exploit-pod": {
 		StoreID:               "",
 		Name:                  "host-read-exploit-pod",
@@ -89,6 +159,36 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"netraw-drop-pod": {
+		StoreID:               "",
+		Name:                  "netraw-drop-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"netraw-pod": {
+		StoreID:               "",
+		Name:                  "netraw-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"node-proxy-pod": {
+		StoreID:               "",
+		Name:                  "node-proxy-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "node-proxy-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"nsenter-pod": {
 		StoreID:               "",
 		Name:                  "nsenter-pod",
@@ -109,6 +209,16 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"pod-debug-pod": {
+		StoreID:               "",
+		Name:                  "pod-debug-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "pod-debug-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"pod-exec-pod": {
 		StoreID:               "",
 		Name:                  "pod-exec-pod",
@@ -129,6 +239,16 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"pod-port-forward-pod": {
+		StoreID:               "",
+		Name:                  "pod-port-forward-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "pod-port-forward-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"priv-pod": {
 		StoreID:               "",
 		Name:                  "priv-pod",
@@ -179,6 +299,16 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"token-create-pod": {
+		StoreID:               "",
+		Name:                  "token-create-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "token-create-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"tokenget-pod": {
 		StoreID:               "",
 		Name:                  "tokenget-pod",
@@ -199,6 +329,26 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"tokensteal-secret-env-pod": {
+		StoreID:               "",
+		Name:                  "tokensteal-secret-env-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"tokensteal-secret-volume-pod": {
+		StoreID:               "",
+		Name:                  "tokensteal-secret-volume-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"umh-core-pod": {
 		StoreID:               "",
 		Name:                  "umh-core-pod",
@@ -219,6 +369,56 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"volume-share-host-reader-pod": {
+		StoreID:               "",
+		Name:                  "volume-share-host-reader-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"volume-share-host-writer-pod": {
+		StoreID:               "",
+		Name:                  "volume-share-host-writer-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"volume-share-pod": {
+		StoreID:               "",
+		Name:                  "volume-share-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"webhook-tamper-pod": {
+		StoreID:               "",
+		Name:                  "webhook-tamper-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "webhook-tamper-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"workload-create-pod": {
+		StoreID:               "",
+		Name:                  "workload-create-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "workload-create-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 }
 
 var expectedNodes = map[string]graph.Node{
@@ -249,6 +449,22 @@ var expectedNodes = map[string]graph.Node{
 }
 
 var expectedVolumes = map[string]graph.Volume{
+	"containerd-dir": {
+		StoreID:    "",
+		Name:       "containerd-dir",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/host/run/containerd",
+		Readonly:   true,
+	},
+	"host-etc-claim": {
+		StoreID:    "",
+		Name:       "host-etc-claim",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/hostetc/",
+		Readonly:   true,
+	},
 	"host-pod-dir": {
 		StoreID:    "",
 		Name:       "host-pod-dir",
@@ -273,6 +489,14 @@ var expectedVolumes = map[string]graph.Volume{
 		MountPath:  "/host/",
 		Readonly:   false,
 	},
+	"legacy-token": {
+		StoreID:    "",
+		Name:       "legacy-token",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/var/run/secrets/tokenlist",
+		Readonly:   false,
+	},
 	"nodelog": {
 		StoreID:    "",
 		Name:       "nodelog",
@@ -289,9 +513,97 @@ var expectedVolumes = map[string]graph.Volume{
 		MountPath:  "/sysproc",
 		Readonly:   false,
 	},
+	"shared-data": {
+		StoreID:    "",
+		Name:       "shared-data",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/data",
+		Readonly:   true,
+	},
+	"shared-host": {
+		StoreID:    "",
+		Name:       "shared-host",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/shared",
+		Readonly:   true,
+	},
 }
 
 var expectedContainers = map[string]graph.Container{
+	"cgroup-release-confined-pod": {
+		StoreID:      "",
+		Name:         "cgroup-release-confined-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "cgroup-release-confined-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"cgroup-release-pod": {
+		StoreID:      "",
+		Name:         "cgroup-release-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "cgroup-release-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"cloud-identity-pod": {
+		StoreID:      "",
+		Name:         "cloud-identity-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "cloud-identity-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"containerd-sock-pod": {
+		StoreID:      "",
+		Name:         "containerd-sock-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "containerd-sock-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
 	"control-pod": {
 		StoreID:      "",
 		Name:         "control-pod",
@@ -310,6 +622,42 @@ var expectedContainers = map[string]graph.Container{
 		// Node:         "",
 		Compromised: 0,
 	},
+	"csr-approve-pod": {
+		StoreID:      "",
+		Name:         "csr-approve-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "csr-approve-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"dac-read-search-pod": {
+		StoreID:      "",
+		Name:         "dac-read-search-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "dac-read-search-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
 	"endpoints-pod": {
 		StoreID:      "",
 		Name:         "endpoints-pod",
@@ -328,6 +676,24 @@ var expectedContainers = map[string]graph.Container{
 		// Node:         "",
 		Compromised: 0,
 	},
+	"host-pvc-read-pod": {
+		StoreID:      "",
+		Name:         "host-pvc-read-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "host-pvc-read-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
 	"host-read-exploit-pod": {
 		StoreID:      "",
 		Name:         "host-read-exploit-pod",
@@ -418,6 +784,60 @@ var expectedContainers = map[string]graph.Container{
 		// Node:         "",
 		Compromised: 0,
 	},
+	"netraw-drop-pod": {
+		StoreID:      "",
+		Name:         "netraw-drop-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "netraw-drop-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"netraw-pod": {
+		StoreID:      "",
+		Name:         "netraw-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "netraw-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"node-proxy-pod": {
+		StoreID:      "",
+		Name:         "node-proxy-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "node-proxy-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
 	"nsenter-pod": {
 		StoreID:      "",
 		Name:         "nsenter-pod",
@@ -454,6 +874,24 @@ var expectedContainers = map[string]graph.Container{
 		// Node:         "",
 		Compromised: 0,
 	},
+	"pod-debug-pod": {
+		StoreID:      "",
+		Name:         "pod-debug-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "pod-debug-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
 	"pod-exec-pod": {
 		StoreID:      "",
 		Name:         "pod-exec-pod",
@@ -490,6 +928,24 @@ var expectedContainers = map[string]graph.Container{
 		// Node:         "",
 		Compromised: 0,
 	},
+	"pod-port-forward-pod": {
+		StoreID:      "",
+		Name:         "pod-port-forward-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "pod-port-forward-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
 	"priv-pod": {
 		StoreID:      "",
 		Name:         "priv-pod",
@@ -634,6 +1090,24 @@ var expectedContainers = map[string]graph.Container{
 		// Node:         "",
 		Compromised: 0,
 	},
+	"token-create-pod": {
+		StoreID:      "",
+		Name:         "token-create-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "token-create-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
 	"tokenget-pod": {
 		StoreID:      "",
 		Name:         "tokenget-pod",
@@ -670,6 +1144,42 @@ var expectedContainers = map[string]graph.Container{
 		// Node:         "",
 		Compromised: 0,
 	},
+	"tokensteal-secret-env-pod": {
+		StoreID:      "",
+		Name:         "tokensteal-secret-env-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "tokensteal-secret-env-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"tokensteal-secret-volume-pod": {
+		StoreID:      "",
+		Name:         "tokensteal-secret-volume-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "tokensteal-secret-volume-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
 	"umh-core-pod": {
 		StoreID:      "",
 		Name:         "umh-core-pod",
@@ -706,4 +1216,112 @@ var expectedContainers = map[string]graph.Container{
 		// Node:         "",
 		Compromised: 0,
 	},
+	"volume-share-host-reader": {
+		StoreID:      "",
+		Name:         "volume-share-host-reader",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "volume-share-host-reader-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"volume-share-host-writer": {
+		StoreID:      "",
+		Name:         "volume-share-host-writer",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "volume-share-host-writer-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"volume-share-reader": {
+		StoreID:      "",
+		Name:         "volume-share-reader",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "volume-share-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"volume-share-writer": {
+		StoreID:      "",
+		Name:         "volume-share-writer",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "volume-share-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"webhook-tamper-pod": {
+		StoreID:      "",
+		Name:         "webhook-tamper-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "webhook-tamper-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
+	"workload-create-pod": {
+		StoreID:      "",
+		Name:         "workload-create-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Ports:        []string{},
+		Pod:          "workload-create-pod",
+		// Node:         "",
+		Compromised: 0,
+	},
 }